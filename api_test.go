@@ -0,0 +1,215 @@
+package emberplus
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// apiFile lists the exported API of the library, one declaration per line. Run the test with -update after adding
+// API, removing or changing a listed declaration breaks downstream users and needs a deprecation shim instead.
+const apiFile = "testdata/api.txt"
+
+//nolint:gochecknoglobals
+var updateAPI = flag.Bool("update", false, "rewrite "+apiFile+" from the current API")
+
+// apiPackages are the packages whose exported API is kept compatible.
+//
+//nolint:gochecknoglobals
+var apiPackages = []string{".", "asn1", "ember", "emberclient", "emberclient/embertest", "s101"}
+
+func TestAPICompatibility(t *testing.T) {
+	t.Parallel()
+
+	var current []string
+
+	for _, dir := range apiPackages {
+		decls, err := exportedAPI(dir)
+		if err != nil {
+			t.Fatalf("exportedAPI(%q) error = %v", dir, err)
+		}
+
+		current = append(current, decls...)
+	}
+
+	sort.Strings(current)
+
+	if *updateAPI {
+		err := os.WriteFile(apiFile, []byte(strings.Join(current, "\n")+"\n"), 0o600)
+		if err != nil {
+			t.Fatalf("failed to update %s: %v", apiFile, err)
+		}
+
+		return
+	}
+
+	data, err := os.ReadFile(apiFile)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", apiFile, err)
+	}
+
+	known := make(map[string]bool, len(current))
+	for _, decl := range current {
+		known[decl] = true
+	}
+
+	listed := make(map[string]bool)
+
+	for _, decl := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		listed[decl] = true
+
+		if !known[decl] {
+			t.Errorf("API removed or changed: %s", decl)
+		}
+	}
+
+	for _, decl := range current {
+		if !listed[decl] {
+			t.Errorf("API added, run go test -run TestAPICompatibility -update: %s", decl)
+		}
+	}
+}
+
+// exportedAPI returns the exported declarations of the package in dir, prefixed with the package name.
+func exportedAPI(dir string) ([]string, error) {
+	fset := token.NewFileSet()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed []*ast.File
+
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, f)
+	}
+
+	if len(parsed) == 0 {
+		return nil, nil
+	}
+
+	// The package is type checked for the types and values of its constants, changing either breaks callers.
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check(dir, fset, parsed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+
+	for _, f := range parsed {
+		for _, decl := range f.Decls {
+			for _, s := range declAPI(fset, pkg, decl) {
+				out = append(out, f.Name.Name+": "+s)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// declAPI returns the exported parts of a top level declaration.
+func declAPI(fset *token.FileSet, pkg *types.Package, decl ast.Decl) []string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if !d.Name.IsExported() || (d.Recv != nil && !exportedReceiver(d.Recv)) {
+			return nil
+		}
+
+		return []string{node(fset, &ast.FuncDecl{Recv: d.Recv, Name: d.Name, Type: d.Type})}
+	case *ast.GenDecl:
+		var out []string
+
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if s.Name.IsExported() {
+					out = append(out, typeAPI(fset, s)...)
+				}
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					if !n.IsExported() {
+						continue
+					}
+
+					line := d.Tok.String() + " " + n.Name
+
+					switch c, ok := pkg.Scope().Lookup(n.Name).(*types.Const); {
+					case ok:
+						line += " " + types.TypeString(c.Type(), types.RelativeTo(pkg)) + " = " + c.Val().ExactString()
+					case s.Type != nil:
+						line += " " + node(fset, s.Type)
+					}
+
+					out = append(out, line)
+				}
+			}
+		}
+
+		return out
+	default:
+		return nil
+	}
+}
+
+// typeAPI lists a type, for structs the exported fields are listed separately, so adding fields is compatible.
+func typeAPI(fset *token.FileSet, s *ast.TypeSpec) []string {
+	st, ok := s.Type.(*ast.StructType)
+	if !ok {
+		return []string{"type " + s.Name.Name + " " + node(fset, s.Type)}
+	}
+
+	out := []string{"type " + s.Name.Name + " struct"}
+
+	for _, f := range st.Fields.List {
+		for _, n := range f.Names {
+			if n.IsExported() {
+				out = append(out, "field "+s.Name.Name+"."+n.Name+" "+node(fset, f.Type))
+			}
+		}
+	}
+
+	return out
+}
+
+// exportedReceiver returns true if the method receiver type is exported.
+func exportedReceiver(recv *ast.FieldList) bool {
+	typ := recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	ident, ok := typ.(*ast.Ident)
+
+	return ok && ident.IsExported()
+}
+
+// node prints an AST node on a single line.
+func node(fset *token.FileSet, n any) string {
+	var b bytes.Buffer
+
+	_ = printer.Fprint(&b, fset, n)
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
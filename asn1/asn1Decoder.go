@@ -142,7 +142,7 @@ func (c *Decoder) Peek() (byte, error) {
 }
 
 // DecodeUniversal decoded the following universal data type of glow, currently only used for universal path decoding,
-// witch is an array of integers.
+// witch is an array of integers encoded as base-128 sub-identifiers.
 func (c *Decoder) DecodeUniversal() ([]int, error) {
 	b, err := c.data.ReadByte()
 	if err != nil {
@@ -158,7 +158,11 @@ func (c *Decoder) DecodeUniversal() ([]int, error) {
 		return nil, fmt.Errorf("failed to read len byte: %w", err)
 	}
 
-	var out []int
+	var (
+		out       []int
+		component int
+		pending   bool
+	)
 
 	for i := 1; i <= lenB; i++ {
 		b, err := c.data.ReadByte()
@@ -166,7 +170,17 @@ func (c *Decoder) DecodeUniversal() ([]int, error) {
 			return nil, fmt.Errorf("failed to read bytes: %w", err)
 		}
 
-		out = append(out, int(b))
+		component = component<<base128Bits | int(b&base128Mask)
+		pending = b&base128More != 0
+
+		if !pending {
+			out = append(out, component)
+			component = 0
+		}
+	}
+
+	if pending {
+		return nil, errors.New("truncated base-128 path component")
 	}
 
 	return out, nil
@@ -252,6 +266,11 @@ func (c *Decoder) readWithOutLength() ([]byte, error) {
 }
 
 func (c *Decoder) readWithLength(length int) ([]byte, error) {
+	// guard against allocating huge buffers for corrupted or hostile length bytes.
+	if length < 0 || length > c.data.Len() {
+		return nil, fmt.Errorf("failed to read bytes with set length, length %d exceeds available data %d", length, c.data.Len())
+	}
+
 	//nolint:makezero
 	out := make([]byte, length)
 
@@ -557,6 +557,26 @@ func TestDecoderDecodeUniversal(t *testing.T) {
 			[]int{1, 0},
 			false,
 		},
+		{
+			"+base128",
+			fields{
+				bytes.NewBuffer(
+					[]byte{
+						UniversalObjectTag, 0x06, 0x01, 0x81, 0x48, 0x81, 0x80, 0x00,
+					},
+				),
+			},
+			[]int{1, 200, 16384},
+			false,
+		},
+		{
+			"-truncatedBase128",
+			fields{
+				bytes.NewBuffer([]byte{UniversalObjectTag, 0x02, 0x01, 0x81}),
+			},
+			nil,
+			true,
+		},
 		{
 			"-emptyBuffer",
 			fields{
@@ -69,14 +69,66 @@ func (c *Encoder) WriteRequest(path []int, tag string, cmd int) error {
 	return nil
 }
 
-// WriteUniversal writes the provided integer into the buffer as an glow encoded universal value.
+// WriteUniversal writes the provided integer into the buffer as an glow encoded universal value. Path components are
+// written as base-128 sub-identifiers, so components above 127 take more than one byte.
 func (c *Encoder) WriteUniversal(path []int) {
-	c.data.WriteByte(UniversalObjectTag)
-	c.data.WriteByte(uint8(len(path)))
+	var encoded []byte
 
 	for _, p := range path {
-		c.data.WriteByte(uint8(p))
+		encoded = appendBase128(encoded, p)
+	}
+
+	c.data.WriteByte(UniversalObjectTag)
+	c.writeLength(len(encoded))
+	c.data.Write(encoded)
+}
+
+// appendBase128 appends the base-128 encoding of a relative OID sub-identifier, most significant group first, with
+// the high bit set on all but the last byte.
+func appendBase128(out []byte, v int) []byte {
+	if v < 0 {
+		v = 0
+	}
+
+	n := 1
+	for tmp := v >> base128Bits; tmp > 0; tmp >>= base128Bits {
+		n++
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		b := byte(v>>(i*base128Bits)) & base128Mask
+		if i > 0 {
+			b |= base128More
+		}
+
+		out = append(out, b)
 	}
+
+	return out
+}
+
+// writeLength writes the length of the following data block, using the short form for lengths up to 127 and the long
+// form otherwise.
+func (c *Encoder) writeLength(length int) {
+	c.data.Write(appendLength(nil, length))
+}
+
+// appendLength appends the encoded length to out, using the short form for lengths up to 127 and the long form
+// otherwise.
+func appendLength(out []byte, length int) []byte {
+	if length <= lenByte {
+		return append(out, uint8(length))
+	}
+
+	var lenBytes []byte
+
+	for tmp := length; tmp > 0; tmp >>= 8 {
+		lenBytes = append([]byte{uint8(tmp)}, lenBytes...)
+	}
+
+	out = append(out, contextByte|uint8(len(lenBytes)))
+
+	return append(out, lenBytes...)
 }
 
 // WriteRootTreeRequest writes a request for root element collection into the buffer.
@@ -108,7 +160,7 @@ func (c *Encoder) WriteCommand(cmd int) error {
 		return fmt.Errorf("failed dir write int: %w", err)
 	}
 
-	if cmd == EmberGetDirCommand {
+	if Command(cmd) == CommandGetDirectory {
 		err = c.writeInt(dirFieldMaskAll, 1)
 		if err != nil {
 			return fmt.Errorf("failed to write dir field mask int: %w", err)
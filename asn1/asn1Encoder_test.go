@@ -244,6 +244,16 @@ func TestEncoderWriteUniversal(t *testing.T) {
 				0x00, 0x00, 0x0D, 0x02, 0x01, 0x02,
 			},
 		},
+		{
+			"+base128",
+			fields{bytes.NewBuffer(nil)},
+			args{
+				[]int{1, 200, 16384},
+			},
+			[]byte{
+				0x0D, 0x06, 0x01, 0x81, 0x48, 0x81, 0x80, 0x00,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,56 @@
+package asn1_test
+
+import (
+	"fmt"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func ExampleEncoder_WriteRootTreeRequest() {
+	encoder := asn1.NewEncoder()
+
+	err := encoder.WriteRootTreeRequest()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("% x\n", encoder.GetData())
+	// Output: 60 80 6b 80 a0 80 62 80 a0 03 02 01 20 a1 03 02 01 ff 00 00 00 00 00 00 00 00
+}
+
+func ExampleEncoder_WriteRequest() {
+	encoder := asn1.NewEncoder()
+
+	err := encoder.WriteRequest([]int{1, 2}, asn1.QualifiedNodeType, asn1.EmberGetDirCommand)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("% x\n", encoder.GetData())
+	// Output: 60 80 6b 80 a0 80 6a 80 a0 80 0d 02 01 02 a2 80 64 80 a0 80 62 80 a0 03 02 01 20 a1 03 02 01 ff 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00
+}
+
+func ExampleDecoder_Read() {
+	decoder := asn1.NewDecoder([]byte{0xa1, 0x04, 0x0c, 0x02, 0x4f, 0x6e})
+
+	content, _, err := decoder.Read(asn1.ContextTagOne, asn1.ContextByte)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	text, err := content.DecodeUTF8()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(text)
+	// Output: On
+}
@@ -0,0 +1,180 @@
+package asn1
+
+import "fmt"
+
+// Command is the number of a Glow command, as sent in the number field of a Command element.
+type Command int
+
+// Glow command numbers.
+const (
+	// CommandSubscribe subscribes to stream updates of a parameter.
+	CommandSubscribe Command = 30
+	// CommandUnsubscribe cancels a subscription created with CommandSubscribe.
+	CommandUnsubscribe Command = 31
+	// CommandGetDirectory requests the children of a node, or the contents of an element.
+	CommandGetDirectory Command = 32
+	// CommandInvoke invokes a function.
+	CommandInvoke Command = 33
+)
+
+// String returns the Glow name of the command.
+func (c Command) String() string {
+	switch c {
+	case CommandSubscribe:
+		return "Subscribe"
+	case CommandUnsubscribe:
+		return "Unsubscribe"
+	case CommandGetDirectory:
+		return "GetDirectory"
+	case CommandInvoke:
+		return "Invoke"
+	default:
+		return fmt.Sprintf("Command(%d)", int(c))
+	}
+}
+
+// Tag is the number of a Glow application tag, identifying the type of the element that follows.
+type Tag uint8
+
+// Glow application tags.
+const (
+	// TagRoot is the tag of the outermost Glow element.
+	TagRoot Tag = 0
+	// TagParameter is the tag of a parameter, identified by its number relative to its parent.
+	TagParameter Tag = 1
+	// TagCommand is the tag of a command.
+	TagCommand Tag = 2
+	// TagNode is the tag of a node, identified by its number relative to its parent.
+	TagNode Tag = 3
+	// TagElementCollection is the tag of a collection of child elements.
+	TagElementCollection Tag = 4
+	// TagStreamEntry is the tag of a single value in a stream collection.
+	TagStreamEntry Tag = 5
+	// TagStreamCollection is the tag of a collection of stream entries.
+	TagStreamCollection Tag = 6
+	// TagStringIntegerPair is the tag of an entry in an enumeration map.
+	TagStringIntegerPair Tag = 7
+	// TagStringIntegerCollection is the tag of an enumeration map.
+	TagStringIntegerCollection Tag = 8
+	// TagQualifiedParameter is the tag of a parameter, identified by its full path.
+	TagQualifiedParameter Tag = 9
+	// TagQualifiedNode is the tag of a node, identified by its full path.
+	TagQualifiedNode Tag = 10
+	// TagRootElementCollection is the tag of the element collection held by the root.
+	TagRootElementCollection Tag = 11
+	// TagStreamDescription is the tag of the description of how a parameter value is packed into a stream.
+	TagStreamDescription Tag = 12
+	// TagMatrix is the tag of a matrix, identified by its number relative to its parent.
+	TagMatrix Tag = 13
+	// TagTarget is the tag of a matrix target.
+	TagTarget Tag = 14
+	// TagSource is the tag of a matrix source.
+	TagSource Tag = 15
+	// TagConnection is the tag of a matrix connection.
+	TagConnection Tag = 16
+	// TagQualifiedMatrix is the tag of a matrix, identified by its full path.
+	TagQualifiedMatrix Tag = 17
+	// TagLabel is the tag of a matrix label.
+	TagLabel Tag = 18
+	// TagFunction is the tag of a function, identified by its number relative to its parent.
+	TagFunction Tag = 19
+	// TagQualifiedFunction is the tag of a function, identified by its full path.
+	TagQualifiedFunction Tag = 20
+	// TagTupleItemDescription is the tag of a function argument or result description.
+	TagTupleItemDescription Tag = 21
+	// TagInvocation is the tag of a function invocation.
+	TagInvocation Tag = 22
+	// TagInvocationResult is the tag of the result of a function invocation.
+	TagInvocationResult Tag = 23
+	// TagTemplate is the tag of a template, identified by its number relative to its parent.
+	TagTemplate Tag = 24
+	// TagQualifiedTemplate is the tag of a template, identified by its full path.
+	TagQualifiedTemplate Tag = 25
+)
+
+//nolint:gochecknoglobals
+var tagNames = map[Tag]string{
+	TagRoot:                    "Root",
+	TagParameter:               "Parameter",
+	TagCommand:                 "Command",
+	TagNode:                    "Node",
+	TagElementCollection:       "ElementCollection",
+	TagStreamEntry:             "StreamEntry",
+	TagStreamCollection:        "StreamCollection",
+	TagStringIntegerPair:       "StringIntegerPair",
+	TagStringIntegerCollection: "StringIntegerCollection",
+	TagQualifiedParameter:      "QualifiedParameter",
+	TagQualifiedNode:           "QualifiedNode",
+	TagRootElementCollection:   "RootElementCollection",
+	TagStreamDescription:       "StreamDescription",
+	TagMatrix:                  "Matrix",
+	TagTarget:                  "Target",
+	TagSource:                  "Source",
+	TagConnection:              "Connection",
+	TagQualifiedMatrix:         "QualifiedMatrix",
+	TagLabel:                   "Label",
+	TagFunction:                "Function",
+	TagQualifiedFunction:       "QualifiedFunction",
+	TagTupleItemDescription:    "TupleItemDescription",
+	TagInvocation:              "Invocation",
+	TagInvocationResult:        "InvocationResult",
+	TagTemplate:                "Template",
+	TagQualifiedTemplate:       "QualifiedTemplate",
+}
+
+// String returns the Glow name of the application tag.
+func (t Tag) String() string {
+	if name, ok := tagNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Tag(%d)", uint8(t))
+}
+
+// ValueType is the Glow parameter type, held in context 13 of a parameter, which defines how the value in context 2
+// is encoded.
+type ValueType int
+
+// Glow parameter value types.
+const (
+	// ValueTypeNull parameter has no value.
+	ValueTypeNull ValueType = 0
+	// ValueTypeInteger parameter holds an integer value.
+	ValueTypeInteger ValueType = 1
+	// ValueTypeReal parameter holds a floating point value.
+	ValueTypeReal ValueType = 2
+	// ValueTypeString parameter holds an utf8 string value.
+	ValueTypeString ValueType = 3
+	// ValueTypeBoolean parameter holds a boolean value.
+	ValueTypeBoolean ValueType = 4
+	// ValueTypeTrigger parameter has no value but can be written to trigger an action.
+	ValueTypeTrigger ValueType = 5
+	// ValueTypeEnum parameter holds an integer selecting one of the enumeration entries.
+	ValueTypeEnum ValueType = 6
+	// ValueTypeOctets parameter holds an octet string value.
+	ValueTypeOctets ValueType = 7
+)
+
+// String returns the Glow name of the value type.
+func (v ValueType) String() string {
+	switch v {
+	case ValueTypeNull:
+		return "null"
+	case ValueTypeInteger:
+		return "integer"
+	case ValueTypeReal:
+		return "real"
+	case ValueTypeString:
+		return "string"
+	case ValueTypeBoolean:
+		return "boolean"
+	case ValueTypeTrigger:
+		return "trigger"
+	case ValueTypeEnum:
+		return "enum"
+	case ValueTypeOctets:
+		return "octets"
+	default:
+		return fmt.Sprintf("ValueType(%d)", int(v))
+	}
+}
@@ -0,0 +1,124 @@
+package asn1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommandString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		c    Command
+		want string
+	}{
+		{"+getDirectory", CommandGetDirectory, "GetDirectory"},
+		{"+unsubscribe", Command(EmberGetUnsubscribeCommand), "Unsubscribe"},
+		{"+subscribe", CommandSubscribe, "Subscribe"},
+		{"+invoke", CommandInvoke, "Invoke"},
+		{"-unknown", 99, "Command(99)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.c.String()); diff != "" {
+				t.Fatalf("Command.String() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestTagString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tag  Tag
+		want string
+	}{
+		{"+qualifiedParameter", Tag(QualifiedParameterTag), "QualifiedParameter"},
+		{"+qualifiedNode", TagQualifiedNode, "QualifiedNode"},
+		{"+rootElementCollection", Tag(RootElementTag), "RootElementCollection"},
+		{"+qualifiedFunction", TagQualifiedFunction, "QualifiedFunction"},
+		{"-unknown", 31, "Tag(31)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.tag.String()); diff != "" {
+				t.Fatalf("Tag.String() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestValueTypeString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    ValueType
+		want string
+	}{
+		{"+integer", ValueTypeInteger, "integer"},
+		{"+boolean", ValueTypeBoolean, "boolean"},
+		{"+octets", ValueTypeOctets, "octets"},
+		{"-unknown", 42, "ValueType(42)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.v.String()); diff != "" {
+				t.Fatalf("ValueType.String() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestLegacyConstantsMatchEnums(t *testing.T) {
+	t.Parallel()
+
+	// the legacy constants are untyped, so callers can use them as any integer type
+	var (
+		_ byte  = RootElementTag
+		_ int64 = EmberGetDirCommand
+	)
+
+	tests := []struct {
+		name  string
+		value int
+		want  int
+	}{
+		{"+getDirectory", EmberGetDirCommand, int(CommandGetDirectory)},
+		{"+unsubscribe", EmberGetUnsubscribeCommand, int(CommandUnsubscribe)},
+		{"+root", RootElementCollectionTag, int(TagRoot)},
+		{"+rootElementCollection", RootElementTag, int(TagRootElementCollection)},
+		{"+elementCollection", ElementCollectionTag, int(TagElementCollection)},
+		{"+qualifiedParameter", QualifiedParameterTag, int(TagQualifiedParameter)},
+		{"+qualifiedNode", QualifiedNodeTag, int(TagQualifiedNode)},
+		{"+command", commandApplicationTag, int(TagCommand)},
+		{"+qualifiedFunction", functionTag, int(TagQualifiedFunction)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.value); diff != "" {
+				t.Fatalf("constant = %s", diff)
+			}
+		})
+	}
+}
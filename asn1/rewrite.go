@@ -0,0 +1,101 @@
+package asn1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// openIndefinite marks an open indefinite length element while scanning for missing closing bytes.
+const openIndefinite = -1
+
+// ToDefinite re-encodes data with definite lengths only, for devices whose indefinite length encoding trips up the
+// decoder.
+func ToDefinite(data []byte) ([]byte, error) {
+	var out []byte
+
+	for len(data) > 0 {
+		tag, content, rest, err := Next(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if tag&constructedBit != 0 {
+			content, err = ToDefinite(content)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out = append(out, tag)
+		out = appendLength(out, len(content))
+		out = append(out, content...)
+		data = rest
+	}
+
+	return out, nil
+}
+
+// CloseIndefinite appends the closing bytes missing at the end of data, for devices ending messages without
+// terminating all indefinite length elements. Data missing nothing is returned unchanged.
+func CloseIndefinite(data []byte) ([]byte, error) {
+	var stack []int
+
+	w := walker{data: data}
+
+	for w.pos < len(data) {
+		for len(stack) > 0 && stack[len(stack)-1] != openIndefinite && w.pos >= stack[len(stack)-1] {
+			stack = stack[:len(stack)-1]
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1] == openIndefinite && w.pos+1 < len(data) &&
+			data[w.pos] == closingByte && data[w.pos+1] == closingByte {
+			w.pos += closingOffset
+			stack = stack[:len(stack)-1]
+
+			continue
+		}
+
+		tag := data[w.pos]
+		w.pos++
+
+		length, indefinite, err := w.length()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case tag&constructedBit == 0 && indefinite:
+			return nil, fmt.Errorf("primitive element with indefinite length at offset %d", w.pos)
+		case tag&constructedBit == 0:
+			w.pos += length
+		case indefinite:
+			stack = append(stack, openIndefinite)
+		default:
+			stack = append(stack, w.pos+length)
+		}
+	}
+
+	if w.pos > len(data) {
+		return nil, errors.New("data ends inside a primitive element")
+	}
+
+	var closing []byte
+
+	for _, end := range stack {
+		if end != openIndefinite {
+			if end > len(data) {
+				return nil, errors.New("data ends inside a definite length element")
+			}
+
+			continue
+		}
+
+		closing = append(closing, closingByte, closingByte)
+	}
+
+	if len(closing) == 0 {
+		return data, nil
+	}
+
+	return append(append(make([]byte, 0, len(data)+len(closing)), data...), closing...), nil
+}
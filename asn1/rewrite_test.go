@@ -0,0 +1,117 @@
+package asn1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToDefinite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []byte
+		wantErr bool
+	}{
+		{
+			"+definite",
+			[]byte{0x60, 0x05, 0xA0, 0x03, 0x02, 0x01, 0x01},
+			[]byte{0x60, 0x05, 0xA0, 0x03, 0x02, 0x01, 0x01},
+			false,
+		},
+		{
+			"+indefinite",
+			[]byte{0x60, 0x80, 0x6B, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00},
+			[]byte{0x60, 0x05, 0x6B, 0x03, 0x02, 0x01, 0x01},
+			false,
+		},
+		{
+			"+longLength",
+			append([]byte{0x60, 0x80, 0x04, 0x81, 0x80}, append(make([]byte, 0x80), 0x00, 0x00)...),
+			append([]byte{0x60, 0x81, 0x83, 0x04, 0x81, 0x80}, make([]byte, 0x80)...),
+			false,
+		},
+		{
+			"-missingEnd",
+			[]byte{0x60, 0x80, 0x02, 0x01, 0x01},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToDefinite(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToDefinite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("ToDefinite() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestCloseIndefinite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []byte
+		wantErr bool
+	}{
+		{
+			"+complete",
+			[]byte{0x60, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00},
+			[]byte{0x60, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00},
+			false,
+		},
+		{
+			"+allMissing",
+			[]byte{0x60, 0x80, 0x6B, 0x80, 0xA0, 0x03, 0x02, 0x01, 0x01},
+			[]byte{0x60, 0x80, 0x6B, 0x80, 0xA0, 0x03, 0x02, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00},
+			false,
+		},
+		{
+			"+outerMissing",
+			[]byte{0x60, 0x80, 0x6B, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00},
+			[]byte{0x60, 0x80, 0x6B, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00},
+			false,
+		},
+		{
+			"-truncatedDefinite",
+			[]byte{0x60, 0x80, 0xA0, 0x05, 0x02, 0x01},
+			nil,
+			true,
+		},
+		{
+			"-truncatedPrimitive",
+			[]byte{0x60, 0x80, 0x04, 0x05, 0x01},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := CloseIndefinite(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CloseIndefinite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("CloseIndefinite() = %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// universal tag of an ASN.1 REAL.
+	realTag = 0x09
+	// first content byte of a binary, base 2 REAL with a one byte exponent, the exponent length is added to it.
+	realBinary = 0x80
+	// bit set in the first content byte of a negative binary REAL.
+	realNegative = 0x40
+	// number of mantissa bits of a float64, including the implicit leading bit.
+	float64MantissaBits = 53
+)
+
+// ErrUnsupportedValue error when a value can not be encoded as a glow parameter value.
+var ErrUnsupportedValue = errors.New("unsupported value type")
+
+// WriteSetValue writes a request into the encoder buffer, which sets the value of the qualified parameter at path.
+// Supported values are integers, float64, bool, string and []byte.
+func (c *Encoder) WriteSetValue(path []int, value any) error {
+	encoded, err := encodeValue(value)
+	if err != nil {
+		return err
+	}
+
+	c.openSequence(ApplicationByte(RootElementCollectionTag))
+	defer c.closeSequence()
+
+	c.openSequence(ApplicationByte(RootElementTag))
+	defer c.closeSequence()
+
+	c.openSequence(ContextByte(0))
+	defer c.closeSequence()
+
+	c.openSequence(ApplicationByte(QualifiedParameterTag))
+	defer c.closeSequence()
+
+	c.openSequence(ContextByte(0))
+	c.WriteUniversal(path)
+	c.closeSequence()
+
+	c.openSequence(ContextByte(ContextTagOne))
+	defer c.closeSequence()
+
+	c.openSequence(SetTag)
+	defer c.closeSequence()
+
+	c.openSequence(ContextByte(ContextTagTwo))
+	defer c.closeSequence()
+
+	c.data.Write(encoded)
+
+	return nil
+}
+
+// encodeValue encodes a glow value as universal ASN.1 type.
+func encodeValue(value any) ([]byte, error) {
+	var (
+		out []byte
+		err error
+	)
+
+	switch v := value.(type) {
+	case int:
+		out, err = asn1.Marshal(int64(v))
+	case int8, int16, int32, int64, uint8, uint16, uint32:
+		out, err = asn1.Marshal(toInt64(v))
+	case float32:
+		out = encodeReal(float64(v))
+	case float64:
+		out = encodeReal(v)
+	case bool:
+		out, err = asn1.Marshal(v)
+	case string:
+		out, err = asn1.MarshalWithParams(v, "utf8")
+	case []byte:
+		out, err = asn1.Marshal(v)
+	default:
+		return nil, fmt.Errorf("%w %T", ErrUnsupportedValue, value)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed native go asn1 marshal: %w", err)
+	}
+
+	return out, nil
+}
+
+// toInt64 converts the fixed size integer types accepted by encodeValue to int64.
+func toInt64(v any) int64 {
+	switch n := v.(type) {
+	case int8:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case uint8:
+		return int64(n)
+	case uint16:
+		return int64(n)
+	case uint32:
+		return int64(n)
+	default:
+		return v.(int64)
+	}
+}
+
+// encodeReal encodes f as binary, base 2 ASN.1 REAL with an odd mantissa, as expected by Ember+ providers.
+func encodeReal(f float64) []byte {
+	switch {
+	case math.IsInf(f, 1):
+		return []byte{realTag, 1, 0x40}
+	case math.IsInf(f, -1):
+		return []byte{realTag, 1, 0x41}
+	case math.IsNaN(f):
+		return []byte{realTag, 1, 0x42}
+	case f == 0 && math.Signbit(f):
+		return []byte{realTag, 1, 0x43}
+	case f == 0:
+		return []byte{realTag, 0}
+	}
+
+	first := byte(realBinary)
+	if f < 0 {
+		first |= realNegative
+		f = -f
+	}
+
+	frac, exponent := math.Frexp(f)
+	mantissa := uint64(math.Ldexp(frac, float64MantissaBits))
+	exponent -= float64MantissaBits
+
+	for mantissa&1 == 0 {
+		mantissa >>= 1
+		exponent++
+	}
+
+	expBytes := minimalSigned(int64(exponent))
+	first |= byte(len(expBytes) - 1)
+
+	content := append([]byte{first}, expBytes...)
+	content = append(content, minimalUnsigned(mantissa)...)
+
+	return append([]byte{realTag, byte(len(content))}, content...)
+}
+
+// minimalSigned returns the shortest big endian two's complement representation of v.
+func minimalSigned(v int64) []byte {
+	out := []byte{byte(v)}
+
+	for v>>7 != 0 && v>>7 != -1 {
+		v >>= 8
+		out = append([]byte{byte(v)}, out...)
+	}
+
+	return out
+}
+
+// minimalUnsigned returns the shortest big endian representation of v.
+func minimalUnsigned(v uint64) []byte {
+	out := []byte{byte(v)}
+
+	for v >>= 8; v > 0; v >>= 8 {
+		out = append([]byte{byte(v)}, out...)
+	}
+
+	return out
+}
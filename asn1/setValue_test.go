@@ -0,0 +1,86 @@
+package asn1
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncoderWriteSetValue(t *testing.T) {
+	t.Parallel()
+
+	prefix := []byte{0x60, 0x80, 0x6b, 0x80, 0xa0, 0x80, 0x69, 0x80, 0xa0, 0x80, 0x0d, 0x02, 0x01, 0x02, 0x00, 0x00,
+		0xa1, 0x80, 0x31, 0x80, 0xa2, 0x80}
+	suffix := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	tests := []struct {
+		name    string
+		value   any
+		want    []byte
+		wantErr error
+	}{
+		{"+int", -6, []byte{0x02, 0x01, 0xfa}, nil},
+		{"+uint8", uint8(200), []byte{0x02, 0x02, 0x00, 0xc8}, nil},
+		{"+bool", true, []byte{0x01, 0x01, 0xff}, nil},
+		{"+string", "On", []byte{0x0c, 0x02, 'O', 'n'}, nil},
+		{"+octets", []byte{0x01}, []byte{0x04, 0x01, 0x01}, nil},
+		{"+real", -2.5, []byte{0x09, 0x03, 0xc0, 0xff, 0x05}, nil},
+		{"-unsupported", struct{}{}, nil, ErrUnsupportedValue},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewEncoder()
+
+			err := c.WriteSetValue([]int{1, 2}, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Encoder.WriteSetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			want := append(append(append([]byte{}, prefix...), tt.want...), suffix...)
+			if diff := cmp.Diff(want, c.GetData()); diff != "" {
+				t.Fatalf("Encoder.WriteSetValue() = %s", diff)
+			}
+		})
+	}
+}
+
+func Test_encodeReal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    float64
+		want []byte
+	}{
+		{"+zero", 0, []byte{0x09, 0x00}},
+		{"+negativeZero", math.Copysign(0, -1), []byte{0x09, 0x01, 0x43}},
+		{"+one", 1, []byte{0x09, 0x03, 0x80, 0x00, 0x01}},
+		{"+half", 0.5, []byte{0x09, 0x03, 0x80, 0xff, 0x01}},
+		{"+large", 1024, []byte{0x09, 0x03, 0x80, 0x0a, 0x01}},
+		{"+fraction", 0.1, []byte{0x09, 0x09, 0x80, 0xc9, 0x0c, 0xcc, 0xcc, 0xcc, 0xcc, 0xcc, 0xcd}},
+		{"+inf", math.Inf(1), []byte{0x09, 0x01, 0x40}},
+		{"+negativeInf", math.Inf(-1), []byte{0x09, 0x01, 0x41}},
+		{"+nan", math.NaN(), []byte{0x09, 0x01, 0x42}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, encodeReal(tt.f)); diff != "" {
+				t.Fatalf("encodeReal() = %s", diff)
+			}
+		})
+	}
+}
@@ -37,16 +37,16 @@ const (
 	FunctionType = "function"
 
 	// EmberGetDirCommand integer for request dir command, based on S101 and glow protocol.
-	EmberGetDirCommand = 32
+	EmberGetDirCommand = 32 // CommandGetDirectory
 	// EmberGetUnsubscribeCommand integer for request Unsubscribe command, based on S101 and glow protocol.
-	EmberGetUnsubscribeCommand = 31
+	EmberGetUnsubscribeCommand = 31 // CommandUnsubscribe
 
 	// RootElementCollectionTag tag for defining glow root element collection encoding command.
-	RootElementCollectionTag = 0
+	RootElementCollectionTag = 0 // TagRoot
 	// RootElementTag tag for defining glow root element collection.
-	RootElementTag = 11
+	RootElementTag = 11 // TagRootElementCollection
 	// ElementCollectionTag tag for element collection.
-	ElementCollectionTag = 4
+	ElementCollectionTag = 4 // TagElementCollection
 	// ContextZeroTag tag for top level context.
 	ContextZeroTag = 0
 	// ContextTagOne tag for context
@@ -56,9 +56,9 @@ const (
 	// Node means data contains children.
 	ContextTagTwo = 2
 	// QualifiedParameterTag for defining glow qualified parameter tag.
-	QualifiedParameterTag = 9
+	QualifiedParameterTag = 9 // TagQualifiedParameter
 	// QualifiedNodeTag for defining glow qualified node tag.
-	QualifiedNodeTag = 10
+	QualifiedNodeTag = 10 // TagQualifiedNode
 
 	// SetTag tag for defining Ember set structure.
 	SetTag = 49
@@ -77,6 +77,12 @@ const (
 	applicationOR = 0x60
 	// byte used in glow data len decoding.
 	lenByte = 0x7F
+	// number of value bits per byte in base-128 encoded path components.
+	base128Bits = 7
+	// mask for the value bits of a base-128 encoded path component byte.
+	base128Mask = 0x7F
+	// bit set on every base-128 encoded path component byte but the last.
+	base128More = 0x80
 
 	// additional option for dir command, based on S101 and glow protocol.
 	dirFieldMaskAll = -1
@@ -85,11 +91,11 @@ const (
 	// maximum length of the bytes that describe the data blocks length in glow encoding.
 	maxLengthBytes = 4
 	// application tag that describes that the glow message is a application command.
-	commandApplicationTag = 2
+	commandApplicationTag = 2 // TagCommand
 	// tag for defining glow element collection tag.
-	elementCollectionTag = 4
+	elementCollectionTag = 4 // TagElementCollection
 	// tag for defining glow function tag.
-	functionTag = 20
+	functionTag = 20 // TagQualifiedFunction
 
 	// tag for defining glow offset when reading all values.
 	closingOffset = 2
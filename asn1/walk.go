@@ -0,0 +1,199 @@
+package asn1
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// classMask selects the class bits of a tag byte.
+	classMask = 0xC0
+	// constructedBit is set in tag bytes of elements containing other elements.
+	constructedBit = 0x20
+	// tagNumberMask selects the tag number bits of a tag byte.
+	tagNumberMask = 0x1F
+	// indefiniteLength is the length byte of elements terminated by two closing bytes.
+	indefiniteLength = 0x80
+)
+
+// ErrStopWalk can be returned by a walk function to end Walk early without an error.
+var ErrStopWalk = errors.New("stop walk")
+
+// WalkFunc is called by Walk for every element, with the tag byte of the element and the tag bytes of all
+// enclosing elements, outermost first. The ancestors slice is only valid during the call.
+type WalkFunc func(tag byte, ancestors []byte) error
+
+// Walk visits every BER element in data depth first, without interpreting the Glow structure, so it also works on
+// payloads containing element types the decoder does not support. Both definite and indefinite lengths are handled.
+func Walk(data []byte, fn WalkFunc) error {
+	w := walker{data: data, fn: fn}
+
+	for w.pos < len(w.data) {
+		err := w.element(nil)
+		if errors.Is(err, ErrStopWalk) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Next splits the first BER element off data, returning its tag byte, its content without tag, length and closing
+// bytes, and the data following it. Both definite and indefinite lengths are handled.
+func Next(data []byte) (byte, []byte, []byte, error) {
+	w := walker{data: data, fn: func(byte, []byte) error { return nil }}
+
+	if len(data) == 0 {
+		return 0, nil, nil, errors.New("unexpected end of data")
+	}
+
+	tag := data[0]
+	w.pos = 1
+
+	_, indefinite, err := w.length()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	start := w.pos
+	w.pos = 0
+
+	err = w.element(nil)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	end := w.pos
+	if indefinite {
+		end -= closingOffset
+	}
+
+	return tag, data[start:end], data[w.pos:], nil
+}
+
+// IsApplication returns true if the tag byte is an application tag, e.g. a Glow element type.
+func IsApplication(tag byte) bool {
+	return tag&classMask == applicationOR&classMask
+}
+
+// IsContext returns true if the tag byte is a context specific tag, e.g. a Glow element field.
+func IsContext(tag byte) bool {
+	return tag&classMask == contextByte
+}
+
+// TagNumber returns the tag number of a tag byte without its class and constructed bits.
+func TagNumber(tag byte) uint8 {
+	return tag & tagNumberMask
+}
+
+// walker holds the read position while walking BER data.
+type walker struct {
+	data []byte
+	pos  int
+	fn   WalkFunc
+}
+
+// element reads the element at the current position, including all its children.
+func (w *walker) element(ancestors []byte) error {
+	if w.pos >= len(w.data) {
+		return errors.New("unexpected end of data")
+	}
+
+	tag := w.data[w.pos]
+	w.pos++
+
+	if tag&tagNumberMask == tagNumberMask {
+		return fmt.Errorf("high tag number form not supported at offset %d", w.pos-1)
+	}
+
+	length, indefinite, err := w.length()
+	if err != nil {
+		return err
+	}
+
+	err = w.fn(tag, ancestors)
+	if err != nil {
+		return err
+	}
+
+	if tag&constructedBit == 0 {
+		if indefinite {
+			return fmt.Errorf("primitive element with indefinite length at offset %d", w.pos)
+		}
+
+		if w.pos+length > len(w.data) {
+			return fmt.Errorf("element length %d exceeds data at offset %d", length, w.pos)
+		}
+
+		w.pos += length
+
+		return nil
+	}
+
+	children := append(ancestors, tag)
+
+	if indefinite {
+		for {
+			if w.pos+1 < len(w.data) && w.data[w.pos] == closingByte && w.data[w.pos+1] == closingByte {
+				w.pos += closingOffset
+
+				return nil
+			}
+
+			err = w.element(children)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	end := w.pos + length
+	if end > len(w.data) {
+		return fmt.Errorf("element length %d exceeds data at offset %d", length, w.pos)
+	}
+
+	for w.pos < end {
+		err = w.element(children)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// length reads the length bytes at the current position.
+func (w *walker) length() (int, bool, error) {
+	if w.pos >= len(w.data) {
+		return 0, false, errors.New("missing length byte")
+	}
+
+	b := w.data[w.pos]
+	w.pos++
+
+	if b == indefiniteLength {
+		return 0, true, nil
+	}
+
+	if b&contextByte == 0 {
+		return int(b), false, nil
+	}
+
+	n := int(b & lenByte)
+	if n > maxLengthBytes || w.pos+n > len(w.data) {
+		return 0, false, fmt.Errorf("invalid length at offset %d", w.pos-1)
+	}
+
+	var out int
+
+	for i := 0; i < n; i++ {
+		out = out<<8 | int(w.data[w.pos])
+		w.pos++
+	}
+
+	return out, false, nil
+}
@@ -0,0 +1,160 @@
+package asn1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	type visit struct {
+		Tag       byte
+		Ancestors []byte
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []visit
+		wantErr bool
+	}{
+		{
+			"+definite",
+			[]byte{0x60, 0x07, 0x6B, 0x05, 0xA0, 0x03, 0x02, 0x01, 0x01},
+			[]visit{
+				{0x60, nil},
+				{0x6B, []byte{0x60}},
+				{0xA0, []byte{0x60, 0x6B}},
+				{0x02, []byte{0x60, 0x6B, 0xA0}},
+			},
+			false,
+		},
+		{
+			"+indefinite",
+			[]byte{0x60, 0x80, 0x6B, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00},
+			[]visit{
+				{0x60, nil},
+				{0x6B, []byte{0x60}},
+				{0x02, []byte{0x60, 0x6B}},
+			},
+			false,
+		},
+		{
+			"+longLength",
+			append([]byte{0x04, 0x81, 0x80}, make([]byte, 0x80)...),
+			[]visit{{0x04, nil}},
+			false,
+		},
+		{
+			"-truncated",
+			[]byte{0x60, 0x05, 0x02, 0x01},
+			[]visit{{0x60, nil}},
+			true,
+		},
+		{
+			"-missingEnd",
+			[]byte{0x60, 0x80, 0x02, 0x01, 0x01},
+			[]visit{{0x60, nil}, {0x02, []byte{0x60}}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []visit
+
+			err := Walk(tt.data, func(tag byte, ancestors []byte) error {
+				got = append(got, visit{tag, append([]byte(nil), ancestors...)})
+
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Walk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("Walk() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestTagClasses(t *testing.T) {
+	t.Parallel()
+
+	if !IsApplication(ApplicationByte(uint8(TagQualifiedMatrix))) || IsApplication(ContextByte(1)) {
+		t.Fatalf("IsApplication() returned wrong class")
+	}
+
+	if !IsContext(ContextByte(14)) || IsContext(SetTag) {
+		t.Fatalf("IsContext() returned wrong class")
+	}
+
+	if diff := cmp.Diff(uint8(TagQualifiedMatrix), TagNumber(ApplicationByte(uint8(TagQualifiedMatrix)))); diff != "" {
+		t.Fatalf("TagNumber() = %s", diff)
+	}
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Tag     byte
+		Content []byte
+		Rest    []byte
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    result
+		wantErr bool
+	}{
+		{
+			"+definite",
+			[]byte{0xA0, 0x03, 0x02, 0x01, 0x05, 0xA1, 0x00},
+			result{0xA0, []byte{0x02, 0x01, 0x05}, []byte{0xA1, 0x00}},
+			false,
+		},
+		{
+			"+indefinite",
+			[]byte{0x65, 0x80, 0xA0, 0x80, 0x02, 0x01, 0x05, 0x00, 0x00, 0x00, 0x00, 0x01},
+			result{0x65, []byte{0xA0, 0x80, 0x02, 0x01, 0x05, 0x00, 0x00}, []byte{0x01}},
+			false,
+		},
+		{
+			"-empty",
+			nil,
+			result{},
+			true,
+		},
+		{
+			"-truncated",
+			[]byte{0x04, 0x05, 0x01},
+			result{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tag, content, rest, err := Next(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, result{tag, content, rest}, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("Next() = %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package ember
+
+import (
+	"strings"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// BackfillParents adds placeholder qualified nodes for all ancestors of elements, which are missing in the
+// collection, e.g. when a provider returns a deep qualified parameter without its parents. The placeholders are
+// marked Incomplete and hold copies of the elements directly below them as children, with paths relative to the
+// placeholder like decoded children, so tree navigation and JSON exports stay hierarchical. The elements stay in the
+// collection under their own path. It returns the number of added nodes.
+func (ec ElementCollection) BackfillParents() int {
+	known := ec.flatten()
+	placeholders := make(map[string]*Element)
+
+	for _, path := range sortedPaths(known) {
+		for parent := parentPath(path); parent != ""; parent = parentPath(parent) {
+			if _, ok := known[parent]; ok {
+				break
+			}
+
+			el := &Element{Path: parent, ElementType: asn1.QualifiedNodeType, Incomplete: true}
+			ec[ElementKey{Path: parent}] = el
+			known[parent] = el
+			placeholders[parent] = el
+		}
+	}
+
+	paths := sortedPaths(known)
+
+	// deeper placeholders are linked first, so the copies in their parents include their children
+	for i := len(paths) - 1; i >= 0; i-- {
+		path := paths[i]
+
+		parent, ok := placeholders[parentPath(path)]
+		if !ok {
+			continue
+		}
+
+		child := *known[path]
+		child.Path = path[strings.LastIndex(path, ".")+1:]
+		parent.Children = append([]*Element{&child}, parent.Children...)
+	}
+
+	return len(placeholders)
+}
@@ -0,0 +1,82 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestElementCollection_BackfillParents(t *testing.T) {
+	t.Parallel()
+
+	gain := &Element{Path: "1.2.3", ElementType: asn1.QualifiedParameterType, Identifier: "gain"}
+	ch1 := &Element{
+		Path:        "1.1",
+		ElementType: asn1.QualifiedNodeType,
+		Identifier:  "CH1",
+		Children:    []*Element{{Path: "4", ElementType: asn1.ParameterType, Identifier: "mute"}},
+	}
+
+	tests := []struct {
+		name      string
+		ec        ElementCollection
+		want      ElementCollection
+		wantAdded int
+	}{
+		{
+			"+complete",
+			ElementCollection{ElementKey{Path: "1"}: &Element{Path: "1", ElementType: asn1.QualifiedNodeType}},
+			ElementCollection{ElementKey{Path: "1"}: &Element{Path: "1", ElementType: asn1.QualifiedNodeType}},
+			0,
+		},
+		{
+			"+missingAncestors",
+			ElementCollection{
+				ElementKey{Path: "1.2.3", ID: "gain"}: gain,
+				ElementKey{Path: "1.1", ID: "CH1"}:    ch1,
+			},
+			ElementCollection{
+				ElementKey{Path: "1.2.3", ID: "gain"}: gain,
+				ElementKey{Path: "1.1", ID: "CH1"}:    ch1,
+				ElementKey{Path: "1"}: &Element{
+					Path:        "1",
+					ElementType: asn1.QualifiedNodeType,
+					Incomplete:  true,
+					Children: []*Element{
+						{Path: "1", ElementType: asn1.QualifiedNodeType, Identifier: "CH1", Children: ch1.Children},
+						{
+							Path:        "2",
+							ElementType: asn1.QualifiedNodeType,
+							Incomplete:  true,
+							Children:    []*Element{{Path: "3", ElementType: asn1.QualifiedParameterType, Identifier: "gain"}},
+						},
+					},
+				},
+				ElementKey{Path: "1.2"}: &Element{
+					Path:        "1.2",
+					ElementType: asn1.QualifiedNodeType,
+					Incomplete:  true,
+					Children:    []*Element{{Path: "3", ElementType: asn1.QualifiedParameterType, Identifier: "gain"}},
+				},
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			added := tt.ec.BackfillParents()
+			if added != tt.wantAdded {
+				t.Fatalf("ElementCollection.BackfillParents() = %d, want %d", added, tt.wantAdded)
+			}
+
+			if diff := cmp.Diff(tt.want, tt.ec); diff != "" {
+				t.Fatalf("ElementCollection.BackfillParents() = %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package ember
+
+import (
+	"fmt"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// Coercion converts the value of a decoded parameter in place, e.g. into a type the application prefers.
+type Coercion func(el *Element) error
+
+// Coercions holds coercions registered per parameter path and per value type, applied by PopulateWithOptions
+// through DecodeOptions. A coercion registered for the path of a parameter takes precedence over one registered for
+// its value type. Coercions must be registered before decoding starts.
+type Coercions struct {
+	paths map[string]Coercion
+	types map[asn1.ValueType]Coercion
+}
+
+// NewCoercions creates an empty coercion registry.
+func NewCoercions() *Coercions {
+	return &Coercions{
+		paths: make(map[string]Coercion),
+		types: make(map[asn1.ValueType]Coercion),
+	}
+}
+
+// ForPath registers fn for the parameter at path.
+func (c *Coercions) ForPath(path string, fn Coercion) error {
+	m, err := CompilePath(path)
+	if err != nil {
+		return err
+	}
+
+	c.paths[m.String()] = fn
+
+	return nil
+}
+
+// ForValueType registers fn for all parameters of the value type. Parameters without a value type are matched by the
+// type of their decoded value.
+func (c *Coercions) ForValueType(vt asn1.ValueType, fn Coercion) {
+	c.types[vt] = fn
+}
+
+// apply runs the coercion registered for the parameter, if it holds a value.
+func (c *Coercions) apply(path string, el *Element) error {
+	if c == nil || !el.HasValue() {
+		return nil
+	}
+
+	fn, ok := c.paths[path]
+	if !ok {
+		fn, ok = c.types[el.valueType()]
+	}
+
+	if !ok {
+		return nil
+	}
+
+	err := fn(el)
+	if err != nil {
+		return fmt.Errorf("failed to coerce value of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// valueType returns the value type of the parameter, derived from its value if the provider sent none.
+func (el *Element) valueType() asn1.ValueType {
+	if el.ValueType != int(asn1.ValueTypeNull) {
+		return asn1.ValueType(el.ValueType)
+	}
+
+	switch el.Value.(type) {
+	case int, int64:
+		return asn1.ValueTypeInteger
+	case float64:
+		return asn1.ValueTypeReal
+	case string:
+		return asn1.ValueTypeString
+	case bool:
+		return asn1.ValueTypeBoolean
+	case []byte:
+		return asn1.ValueTypeOctets
+	default:
+		return asn1.ValueTypeNull
+	}
+}
+
+// FactorToFloat is a Coercion turning integer parameters with a factor into float64 values divided by the factor,
+// e.g. -125 with factor 10 into -12.5. Minimum, maximum and default are scaled as well and the factor is cleared, so
+// it is not applied twice by DisplayValue.
+func FactorToFloat(el *Element) error {
+	if el.Factor <= 1 {
+		return nil
+	}
+
+	factor := float64(el.Factor)
+
+	for _, v := range []*any{&el.Value, &el.Minimum, &el.Maximum, &el.Default} {
+		switch n := (*v).(type) {
+		case int:
+			*v = float64(n) / factor
+		case int64:
+			*v = float64(n) / factor
+		}
+	}
+
+	el.Factor = 0
+
+	return nil
+}
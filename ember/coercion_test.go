@@ -0,0 +1,113 @@
+package ember
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// onAir is an application defined enum, as targeted by enum coercions.
+type onAir int
+
+func TestDecodeOptions_Coercions(t *testing.T) {
+	t.Parallel()
+
+	errCoerce := errors.New("coerce")
+
+	newCollection := func() ElementCollection {
+		return ElementCollection{
+			ElementKey{Path: "1"}: &Element{
+				Path:        "1",
+				ElementType: asn1.QualifiedNodeType,
+				Children: []*Element{
+					{Path: "1", ElementType: asn1.ParameterType, Value: int64(-125), Minimum: int64(-900), Factor: 10},
+					{Path: "2", ElementType: asn1.ParameterType, Value: int64(1), ValueType: int(asn1.ValueTypeEnum)},
+					{Path: "3", ElementType: asn1.ParameterType, Value: int64(7), Factor: 10},
+					{Path: "4", ElementType: asn1.ParameterType, ValueType: int(asn1.ValueTypeEnum), NullValue: true},
+				},
+			},
+		}
+	}
+
+	toOnAir := func(el *Element) error {
+		el.Value = onAir(el.Value.(int64))
+
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		coercions func() *Coercions
+		want      []*Element
+		wantErr   error
+	}{
+		{
+			"+none",
+			func() *Coercions { return nil },
+			newCollection()[ElementKey{Path: "1"}].Children,
+			nil,
+		},
+		{
+			"+pathAndType",
+			func() *Coercions {
+				c := NewCoercions()
+				c.ForValueType(asn1.ValueTypeInteger, FactorToFloat)
+				c.ForValueType(asn1.ValueTypeEnum, toOnAir)
+				_ = c.ForPath("1/3", func(*Element) error { return nil })
+
+				return c
+			},
+			[]*Element{
+				{Path: "1", ElementType: asn1.ParameterType, Value: -12.5, Minimum: -90.0},
+				{Path: "2", ElementType: asn1.ParameterType, Value: onAir(1), ValueType: int(asn1.ValueTypeEnum)},
+				{Path: "3", ElementType: asn1.ParameterType, Value: int64(7), Factor: 10},
+				{Path: "4", ElementType: asn1.ParameterType, ValueType: int(asn1.ValueTypeEnum), NullValue: true},
+			},
+			nil,
+		},
+		{
+			"-error",
+			func() *Coercions {
+				c := NewCoercions()
+				_ = c.ForPath("1.2", func(*Element) error { return errCoerce })
+
+				return c
+			},
+			nil,
+			errCoerce,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ec := newCollection()
+
+			err := ec.Sanitize(DecodeOptions{Coercions: tt.coercions()})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ElementCollection.Sanitize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, ec[ElementKey{Path: "1"}].Children); diff != "" {
+				t.Fatalf("ElementCollection.Sanitize() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestCoercions_ForPathInvalid(t *testing.T) {
+	t.Parallel()
+
+	err := NewCoercions().ForPath("1..2", FactorToFloat)
+	if !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("Coercions.ForPath() error = %v, want %v", err, ErrInvalidPath)
+	}
+}
@@ -0,0 +1,171 @@
+package ember
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// UnitPlacement defines where the unit taken from a parameter format is placed relative to the number.
+type UnitPlacement int
+
+const (
+	// UnitAfter places the unit after the number, e.g. "-12.5 dB".
+	UnitAfter UnitPlacement = iota
+	// UnitBefore places the unit before the number, e.g. "dB -12.5".
+	UnitBefore
+	// UnitOmitted drops the unit and only returns the number.
+	UnitOmitted
+)
+
+// NumberFormat configures how numeric parameter values are rendered for display and reports.
+type NumberFormat struct {
+	// DecimalSeparator replaces the decimal point, e.g. "," for most European locales. Defaults to ".".
+	DecimalSeparator string
+	// UnitPlacement defines where the unit is placed relative to the number.
+	UnitPlacement UnitPlacement
+	// UnitSeparator is put between number and unit. Defaults to " ".
+	UnitSeparator string
+}
+
+// DefaultNumberFormat renders numbers with a decimal point and the unit after the number.
+//
+//nolint:gochecknoglobals
+var DefaultNumberFormat = NumberFormat{DecimalSeparator: ".", UnitPlacement: UnitAfter, UnitSeparator: " "}
+
+// formatVerb matches the printf style conversion inside an Ember+ parameter format, e.g. "%8.2f" in "%8.2f dB".
+//
+//nolint:gochecknoglobals
+var formatVerb = regexp.MustCompile(`%[-+ #0]*[0-9]*(\.[0-9]+)?[dfeEgGsvx]`)
+
+// DisplayValue returns the parameter value as a human readable string. Integer values are divided by the parameter
+// factor, enum values are resolved to their enumeration label and the parameter format is applied, with decimal
+// separator and unit placement taken from nf.
+func (el *Element) DisplayValue(nf NumberFormat) string {
+	if el.Value == nil {
+		return ""
+	}
+
+	if label, ok := el.enumLabel(); ok {
+		return label
+	}
+
+	number, ok := el.numericValue()
+	if !ok {
+		return fmt.Sprintf("%v", el.Value)
+	}
+
+	verb, unit := splitFormat(el.Format)
+
+	var text string
+
+	switch {
+	case verb == "":
+		text = strconv.FormatFloat(number, 'f', -1, 64)
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "v"):
+		text = strings.TrimSpace(fmt.Sprintf(verb, strconv.FormatFloat(number, 'f', -1, 64)))
+	case strings.HasSuffix(verb, "d"), strings.HasSuffix(verb, "x"):
+		text = strings.TrimSpace(fmt.Sprintf(verb, int64(number)))
+	default:
+		text = strings.TrimSpace(fmt.Sprintf(verb, number))
+	}
+
+	return nf.apply(text, unit)
+}
+
+// enumLabel returns the enumeration entry selected by an integer value.
+func (el *Element) enumLabel() (string, bool) {
+	if el.Enumeration == "" {
+		return "", false
+	}
+
+	idx, ok := toFloat(el.Value)
+	if !ok {
+		return "", false
+	}
+
+	labels := strings.Split(el.Enumeration, "\n")
+	if idx < 0 || int(idx) >= len(labels) {
+		return "", false
+	}
+
+	return labels[int(idx)], true
+}
+
+// numericValue returns the value as float, scaled by the parameter factor for integer values. Ember+ defines the
+// factor for integer parameters only, real values are returned unscaled.
+func (el *Element) numericValue() (float64, bool) {
+	number, ok := toFloat(el.Value)
+	if !ok {
+		return 0, false
+	}
+
+	if el.Factor > 1 && isInteger(el.Value) {
+		number /= float64(el.Factor)
+	}
+
+	return number, true
+}
+
+// splitFormat splits an Ember+ parameter format into its printf conversion and the unit text surrounding it.
+func splitFormat(format string) (string, string) {
+	loc := formatVerb.FindStringIndex(format)
+	if loc == nil {
+		return "", strings.TrimSpace(format)
+	}
+
+	unit := strings.TrimSpace(format[:loc[0]] + " " + format[loc[1]:])
+
+	return format[loc[0]:loc[1]], unit
+}
+
+// apply localizes a formatted number and combines it with the unit.
+func (nf NumberFormat) apply(number string, unit string) string {
+	if nf.DecimalSeparator != "" && nf.DecimalSeparator != "." {
+		number = strings.Replace(number, ".", nf.DecimalSeparator, 1)
+	}
+
+	if unit == "" || nf.UnitPlacement == UnitOmitted {
+		return number
+	}
+
+	sep := nf.UnitSeparator
+	if sep == "" {
+		sep = " "
+	}
+
+	if nf.UnitPlacement == UnitBefore {
+		return unit + sep + number
+	}
+
+	return number + sep + unit
+}
+
+// toFloat converts the numeric value types produced by the decoder to float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// isInteger returns true if v holds a decoded integer value.
+func isInteger(v any) bool {
+	switch v.(type) {
+	case int, int64, int32:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestElement_DisplayValue(t *testing.T) {
+	t.Parallel()
+
+	european := NumberFormat{DecimalSeparator: ",", UnitPlacement: UnitAfter, UnitSeparator: " "}
+
+	tests := []struct {
+		name string
+		el   *Element
+		nf   NumberFormat
+		want string
+	}{
+		{
+			"+noValue",
+			&Element{},
+			DefaultNumberFormat,
+			"",
+		},
+		{
+			"+plainInteger",
+			&Element{Value: 42},
+			DefaultNumberFormat,
+			"42",
+		},
+		{
+			"+formatWithUnit",
+			&Element{Value: -125, Factor: 10, Format: "%.1f dB"},
+			DefaultNumberFormat,
+			"-12.5 dB",
+		},
+		{
+			"+realIgnoresFactor",
+			&Element{Value: -12.5, Factor: 10, Format: "%.1f dB"},
+			DefaultNumberFormat,
+			"-12.5 dB",
+		},
+		{
+			"+europeanDecimal",
+			&Element{Value: -125, Factor: 10, Format: "%.1f dB"},
+			european,
+			"-12,5 dB",
+		},
+		{
+			"+stringVerb",
+			&Element{Value: 5, Format: "%s dB"},
+			DefaultNumberFormat,
+			"5 dB",
+		},
+		{
+			"+valueVerb",
+			&Element{Value: -125, Factor: 10, Format: "%v dB"},
+			european,
+			"-12,5 dB",
+		},
+		{
+			"+unitBefore",
+			&Element{Value: int64(3), Format: "%d ms"},
+			NumberFormat{UnitPlacement: UnitBefore},
+			"ms 3",
+		},
+		{
+			"+unitOmitted",
+			&Element{Value: 0.5, Format: "%.2f %"},
+			NumberFormat{UnitPlacement: UnitOmitted},
+			"0.50",
+		},
+		{
+			"+unitOnlyFormat",
+			&Element{Value: 48000, Format: "Hz"},
+			european,
+			"48000 Hz",
+		},
+		{
+			"+enum",
+			&Element{Value: 1, Enumeration: "Off\nOn"},
+			DefaultNumberFormat,
+			"On",
+		},
+		{
+			"+enumOutOfRange",
+			&Element{Value: 5, Enumeration: "Off\nOn"},
+			DefaultNumberFormat,
+			"5",
+		},
+		{
+			"+string",
+			&Element{Value: "Studio A"},
+			european,
+			"Studio A",
+		},
+		{
+			"+bool",
+			&Element{Value: true},
+			DefaultNumberFormat,
+			"true",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.el.DisplayValue(tt.nf)); diff != "" {
+				t.Fatalf("Element.DisplayValue() = %s", diff)
+			}
+		})
+	}
+}
@@ -27,22 +27,31 @@ import (
 
 const (
 	// tag for defining glow node tag.
-	nodeTag = 3
+	nodeTag = 3 // asn1.TagNode
 	// tag for defining glow function tag.
-	functionTag = 20
+	functionTag = 20 // asn1.TagQualifiedFunction
 	// parameterTag glow  parameter tag.
-	parameterTag = 1
+	parameterTag = 1 // asn1.TagParameter
 
 	// node values types held in context(13), define what is the type of value in context(2).
-	valueTypeInt    = 1
-	valueTypeReal   = 2
-	valueTypeString = 3
-	valueTypeBool   = 4
-	valueTypeEnum   = 6
+	valueTypeInt    = 1 // asn1.ValueTypeInteger
+	valueTypeReal   = 2 // asn1.ValueTypeReal
+	valueTypeString = 3 // asn1.ValueTypeString
+	valueTypeBool   = 4 // asn1.ValueTypeBoolean
+	valueTypeEnum   = 6 // asn1.ValueTypeEnum
+
+	// universal tag of an ASN.1 NULL, sent by some providers as value of parameters without value.
+	nullTag = 0x05
 )
 
-// ErrElementNotFound error when element is not found.
-var ErrElementNotFound = errors.New("element not found")
+var (
+	// ErrElementNotFound error when element is not found.
+	ErrElementNotFound = errors.New("element not found")
+	// ErrDecodePanic error returned by SafePopulate when decoding malformed data panicked.
+	ErrDecodePanic = errors.New("panic while decoding glow data")
+	// ErrInvalidPath error when a path string can not be parsed into an oid.
+	ErrInvalidPath = errors.New("invalid path")
+)
 
 // node hold information about node and qualified node parameter fields.
 type node struct {
@@ -53,6 +62,7 @@ type node struct {
 	Description string      `json:"description"`
 	IsOnline    bool        `json:"is_online"`
 	IsRoot      bool        `json:"is_root"`
+	Incomplete  bool        `json:"incomplete,omitempty"`
 }
 
 // function hold information about function parameter fields.
@@ -104,10 +114,26 @@ type Element struct {
 	Factor      int
 	Default     any
 	ValueType   int
+	// IsStreamed is true if the parameter value is sent in stream collections with StreamIdentifier.
+	IsStreamed       bool `json:"-"`
+	StreamIdentifier int  `json:"-"`
+	// StreamDescriptor locates the value in octet string stream entries shared by several parameters.
+	StreamDescriptor *StreamDescriptor `json:"-"`
+	// RawStrings holds the original bytes of strings sanitized with the UTF8Raw policy, keyed by field name.
+	RawStrings map[string][]byte `json:"-"`
+	// Incomplete is true for placeholder nodes synthesized by BackfillParents, which were not sent by the provider.
+	Incomplete bool `json:"-"`
+	// NullValue is true if the provider sent an explicit ASN.1 NULL as value, no default value is set then.
+	NullValue bool `json:"-"`
+}
+
+// HasValue returns false if the parameter holds no value, e.g. because the provider sent an explicit NULL.
+func (el *Element) HasValue() bool {
+	return el.Value != nil && !el.NullValue
 }
 
 func (el *Element) ToString() string {
-	return fmt.Sprintf("Path: %v, EType: %v, Id: %v, Desc: %v, IsOnline: %v, IsRoot: %v, Value: %v, ValueType: %v", el.Path, el.ElementType, el.Identifier, el.Description, el.IsOnline, el.IsRoot, el.Value, el.ValueType)
+	return fmt.Sprintf("Path: %v, EType: %v, Id: %v, Desc: %v, IsOnline: %v, IsRoot: %v, Value: %v, ValueType: %v", el.Path, el.ElementType, el.Identifier, el.Description, el.IsOnline, el.IsRoot, el.Value, asn1.ValueType(el.ValueType))
 }
 
 //nolint:gocyclo,cyclop
@@ -362,7 +388,7 @@ func getElement(d *asn1.Decoder) (*Element, *asn1.Decoder, error) {
 	case asn1.ApplicationByte(functionTag):
 		el.ElementType = asn1.FunctionType
 	default:
-		return nil, nil, fmt.Errorf("unknown type: %x", t)
+		return nil, nil, fmt.Errorf("unknown type: %x (%v)", t, asn1.Tag(t&^asn1.ApplicationByte(0)))
 	}
 
 	decoder, err = el.handleApplication(decoder)
@@ -617,20 +643,29 @@ func (el *Element) handleParameterContext(context *asn1.Decoder, tag byte) (*asn
 		el.ValueType = valType
 
 	case asn1.ContextByte(14):
-		context, err = readOverElement(context)
+		var streamID int
+
+		streamID, err = context.DecodeInteger()
 		if err != nil {
-			return nil, fmt.Errorf("failed to skip element at %x: %w", asn1.ContextByte(14), err)
+			return nil, fmt.Errorf("failed to decode stream identifier: %w", err)
 		}
+
+		el.IsStreamed = true
+		el.StreamIdentifier = streamID
 	case asn1.ContextByte(15):
 		context, err = readOverElement(context)
 		if err != nil {
 			return nil, fmt.Errorf("failed to skip element at %x: %w", asn1.ContextByte(15), err)
 		}
 	case asn1.ContextByte(16):
-		context, err = readOverElement(context)
+		var sd StreamDescriptor
+
+		n, err = decodeStreamDescriptor(context.Bytes(), &sd)
 		if err != nil {
-			return nil, fmt.Errorf("failed to skip element at %x: %w", asn1.ContextByte(16), err)
+			return nil, fmt.Errorf("failed to decode stream descriptor: %w", err)
 		}
+
+		el.StreamDescriptor = &sd
 	case asn1.ContextByte(17):
 		context, err = readOverElement(context)
 		if err != nil {
@@ -718,6 +753,13 @@ func (el *Element) setValue(context *asn1.Decoder) (any, int, error) {
 		err error
 	)
 
+	data := context.Bytes()
+	el.NullValue = len(data) >= 2 && data[0] == nullTag && data[1] == 0
+
+	if el.NullValue {
+		return nil, 2, nil
+	}
+
 	switch el.ValueType {
 	case valueTypeInt, valueTypeEnum:
 		out, err = context.DecodeInteger()
@@ -757,7 +799,7 @@ func (el *Element) setValue(context *asn1.Decoder) (any, int, error) {
 func (el *Element) setDefaultElementValue() {
 	// no default for enum data type as value for enum data type defines witch of string lines in enum field to use.
 	// and none should be used if there no value
-	if el.Value == nil {
+	if el.Value == nil && !el.NullValue {
 		switch el.ValueType {
 		case valueTypeInt, valueTypeReal:
 			el.Value = 0
@@ -838,22 +880,53 @@ func readOverElement(decoder *asn1.Decoder) (*asn1.Decoder, error) {
 	}
 }
 
-// parsePath returns string oid path as integer array.
+// ParsePath parses a path string such as "1.2.3" or "1/2/3" into its numeric components, returning an error wrapping
+// ErrInvalidPath that names the offending component if the path is malformed.
+func ParsePath(path string) ([]int, error) {
+	return parsePath(path)
+}
+
+// parsePath returns string oid path as integer array. Components may be separated by either "." or "/" and may be
+// surrounded by whitespace, e.g. "1.2.3", "1/2/3" or " 1. 2 .3 ".
 func parsePath(path string) ([]int, error) {
-	if path == "" {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
 		return nil, nil
 	}
 
-	paths := strings.Split(path, ".")
+	separator := "."
+
+	if strings.Contains(trimmed, "/") {
+		if strings.Contains(trimmed, ".") {
+			return nil, fmt.Errorf("%w %q: mixes '.' and '/' separators", ErrInvalidPath, path)
+		}
+
+		separator = "/"
+	}
+
+	paths := strings.Split(trimmed, separator)
 	out := make([]int, 0, len(paths))
 
-	for _, p := range paths {
-		i, err := strconv.Atoi(p)
+	for n, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return nil, fmt.Errorf("%w %q: component %d is empty", ErrInvalidPath, path, n+1)
+		}
+
+		i, err := strconv.ParseInt(p, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse path component")
+			if errors.Is(err, strconv.ErrRange) {
+				return nil, fmt.Errorf("%w %q: component %d %q is out of range", ErrInvalidPath, path, n+1, p)
+			}
+
+			return nil, fmt.Errorf("%w %q: component %d %q is not a number", ErrInvalidPath, path, n+1, p)
+		}
+
+		if i < 0 {
+			return nil, fmt.Errorf("%w %q: component %d %q is negative", ErrInvalidPath, path, n+1, p)
 		}
 
-		out = append(out, i)
+		out = append(out, int(i))
 	}
 
 	return out, nil
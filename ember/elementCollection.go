@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/johannes-kuhfuss/emberplus/asn1"
 	"github.com/johannes-kuhfuss/emberplus/s101"
@@ -30,7 +31,8 @@ type ElementKey struct {
 	Path string
 }
 
-// ElementCollection contains one level of elements and their Ids as key.
+// ElementCollection contains one level of elements and their Ids as key. Like any map it is not safe for concurrent
+// use, Populate and other writers need exclusive access. Use SyncCollection when several goroutines share a collection.
 type ElementCollection map[ElementKey]*Element
 
 // Populate fills in collection with data from the decoder.
@@ -39,6 +41,10 @@ type ElementCollection map[ElementKey]*Element
 func (ec ElementCollection) Populate(data *asn1.Decoder) error {
 	var end bool
 
+	if data == nil {
+		return errors.New("failed to populate from nil decoder")
+	}
+
 	app0Codec, _, err := data.Read(asn1.RootElementCollectionTag, asn1.ApplicationByte)
 	if err != nil {
 		return fmt.Errorf("failed to read element root collection tag: %w", err)
@@ -105,22 +111,22 @@ func (ec ElementCollection) Populate(data *asn1.Decoder) error {
 	return nil
 }
 
-// GetElementByPath returns element from collection with the provided path OID.
-func (ec ElementCollection) GetElementByPath(currentPath string) (*Element, error) {
-	for key, el := range ec {
-		if key.Path == currentPath {
-			return el, nil
+// SafePopulate works like Populate, but recovers from any panic raised while decoding malformed data and returns it
+// as an error wrapping ErrDecodePanic instead, so a single bad frame can not take down a long-running process.
+func (ec ElementCollection) SafePopulate(data *asn1.Decoder) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrDecodePanic, r)
 		}
+	}()
 
-		for _, ch := range el.Children {
-			childPath := fmt.Sprintf("%s.%s", key.Path, ch.Path)
-			if childPath == currentPath {
-				return ch, nil
-			}
-		}
-	}
+	return ec.Populate(data)
+}
 
-	return nil, fmt.Errorf("failed to find element with path %q: %w", currentPath, ErrElementNotFound)
+// GetElementByPath returns element from collection with the provided path OID. Use GetElementByMatcher when looking
+// up the same path repeatedly.
+func (ec ElementCollection) GetElementByPath(currentPath string) (*Element, error) {
+	return ec.GetElementByMatcher(PathMatcher{text: currentPath})
 }
 
 // GetElementByID returns element from collection with the provided identifier.
@@ -155,6 +161,7 @@ func (ec ElementCollection) MarshalJSON() ([]byte, error) {
 				Children:    v.Children,
 				IsOnline:    v.IsOnline,
 				IsRoot:      v.IsRoot,
+				Incomplete:  v.Incomplete,
 			}
 		case asn1.ParameterType, asn1.QualifiedParameterType:
 			out[k.Path] = parameter{
@@ -194,11 +201,71 @@ func (ec ElementCollection) MarshalJSON() ([]byte, error) {
 	return bytes, nil
 }
 
-// NewElementConnection creates a empty element collection.
-func NewElementConnection() ElementCollection {
+// flatten returns all elements of the collection including their direct children, keyed by their full path.
+func (ec ElementCollection) flatten() map[string]*Element {
+	out := make(map[string]*Element, len(ec))
+
+	for key, el := range ec {
+		out[key.Path] = el
+
+		for _, ch := range el.Children {
+			out[fmt.Sprintf("%s.%s", key.Path, ch.Path)] = ch
+		}
+	}
+
+	return out
+}
+
+// sortedPaths returns the keys of a flattened collection in numeric path order.
+func sortedPaths(elements map[string]*Element) []string {
+	paths := make([]string, 0, len(elements))
+	for path := range elements {
+		paths = append(paths, path)
+	}
+
+	SortPaths(paths)
+
+	return paths
+}
+
+// SortPaths sorts paths by their numeric components, so "1.2" sorts before "1.10". Malformed paths are compared as
+// text.
+func SortPaths(paths []string) {
+	sort.Slice(paths, func(i, j int) bool {
+		return lessPath(paths[i], paths[j])
+	})
+}
+
+// lessPath compares two paths by their numeric components, so "1.2" sorts before "1.10".
+func lessPath(a string, b string) bool {
+	pa, errA := ParsePath(a)
+	pb, errB := ParsePath(b)
+
+	if errA != nil || errB != nil {
+		return a < b
+	}
+
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] {
+			return pa[i] < pb[i]
+		}
+	}
+
+	return len(pa) < len(pb)
+}
+
+// NewElementCollection creates an empty element collection.
+func NewElementCollection() ElementCollection {
 	return make(ElementCollection)
 }
 
+// NewElementConnection creates an empty element collection.
+//
+// Deprecated: Use NewElementCollection, this name is kept for compatibility.
+func NewElementConnection() ElementCollection {
+	return NewElementCollection()
+}
+
 // GetRootRequest returns a S101 request packet with an encoded request for root collection.
 func GetRootRequest() ([]byte, error) {
 	encoder := asn1.NewEncoder()
@@ -227,3 +294,21 @@ func GetRequestByType(et ElementType, path string) ([]byte, error) {
 
 	return s101.Encode(encoder.GetData(), s101.FirstMultiPacket), nil
 }
+
+// GetSetValueRequest returns S101 packet with an encoded request, which sets the value of the parameter with the
+// provided path.
+func GetSetValueRequest(path string, value any) ([]byte, error) {
+	encoder := asn1.NewEncoder()
+
+	parsed, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse path: %w", err)
+	}
+
+	err = encoder.WriteSetValue(parsed, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write set value request: %w", err)
+	}
+
+	return s101.Encode(encoder.GetData(), s101.FirstMultiPacket), nil
+}
@@ -16,6 +16,7 @@
 package ember
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -784,6 +785,74 @@ func TestElementCollection_Populate(t *testing.T) {
 	}
 }
 
+func TestElementCollection_SafePopulate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      *asn1.Decoder
+		want      ElementCollection
+		wantPanic bool
+		wantErr   bool
+	}{
+		{
+			"+valid",
+			asn1.NewDecoder([]byte{
+				0x60, 0x34, 0x6B, 0x32, 0xA0, 0x30, 0x63, 0x2E, 0xA0, 0x03, 0x02, 0x01, 0x01, 0xA1, 0x27, 0x31,
+				0x25, 0xA0, 0x16, 0x0C, 0x14, 0x52, 0x33, 0x4C, 0x41, 0x59, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61,
+				0x6C, 0x50, 0x61, 0x74, 0x63, 0x68, 0x42, 0x61, 0x79, 0xA1, 0x02, 0x0C, 0x00, 0xA4, 0x02, 0x0C,
+				0x00, 0xA3, 0x03, 0x01, 0x01, 0xFF,
+			}),
+			ElementCollection{
+				ElementKey{Path: "1", ID: "R3LAYVirtualPatchBay"}: &Element{
+					Path:        "1",
+					ElementType: asn1.NodeType,
+					Identifier:  "R3LAYVirtualPatchBay",
+					IsOnline:    true,
+				},
+			},
+			false,
+			false,
+		},
+		{
+			"-nilDecoder",
+			nil,
+			ElementCollection{},
+			false,
+			true,
+		},
+		{
+			"-panicRecovered",
+			&asn1.Decoder{},
+			ElementCollection{},
+			true,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ec := NewElementCollection()
+
+			err := ec.SafePopulate(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ElementCollection.SafePopulate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if errors.Is(err, ErrDecodePanic) != tt.wantPanic {
+				t.Fatalf("ElementCollection.SafePopulate() error = %v, wantPanic %v", err, tt.wantPanic)
+			}
+
+			if diff := cmp.Diff(tt.want, ec); diff != "" {
+				t.Fatalf("ElementCollection.SafePopulate() = %s", diff)
+			}
+		})
+	}
+}
+
 func TestElementCollection_GetElementByPath(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,7 @@
 package ember
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -2700,7 +2701,7 @@ func TestElement_handleParameterContext(t *testing.T) {
 				),
 				14,
 			},
-			&Element{},
+			&Element{IsStreamed: true, StreamIdentifier: 4},
 			asn1.NewDecoder([]byte{}),
 			false,
 		},
@@ -2722,14 +2723,38 @@ func TestElement_handleParameterContext(t *testing.T) {
 			fields{},
 			args{
 				asn1.NewDecoder(
-					[]byte{0x02, 0x01, 0x04},
+					[]byte{0x6C, 0x0A, 0xA0, 0x03, 0x02, 0x01, 0x15, 0xA1, 0x03, 0x02, 0x01, 0x04},
 				),
 				16,
 			},
-			&Element{},
+			&Element{StreamDescriptor: &StreamDescriptor{Format: StreamFloat32LE, Offset: 4}},
 			asn1.NewDecoder([]byte{}),
 			false,
 		},
+		{
+			"+context16Indefinite",
+			fields{},
+			args{
+				asn1.NewDecoder(
+					[]byte{
+						0x6C, 0x80, 0xA0, 0x80, 0x02, 0x01, 0x15, 0x00, 0x00, 0xA1, 0x03, 0x02, 0x01, 0x04, 0x00, 0x00,
+						0x02, 0x01, 0x04,
+					},
+				),
+				16,
+			},
+			&Element{StreamDescriptor: &StreamDescriptor{Format: StreamFloat32LE, Offset: 4}},
+			asn1.NewDecoder([]byte{0x02, 0x01, 0x04}),
+			false,
+		},
+		{
+			"-context16IndefiniteUnterminated",
+			fields{},
+			args{asn1.NewDecoder([]byte{0x6C, 0x80, 0xA0, 0x03, 0x02, 0x01, 0x15}), 16},
+			&Element{},
+			asn1.NewDecoder(nil),
+			true,
+		},
 		{
 			"+context17",
 			fields{},
@@ -3264,6 +3289,62 @@ func Test_parsePath(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"+slashSeparated",
+			args{
+				"1/2/3",
+			},
+			[]int{1, 2, 3},
+			false,
+		},
+		{
+			"+whitespace",
+			args{
+				" 1. 2 .3 ",
+			},
+			[]int{1, 2, 3},
+			false,
+		},
+		{
+			"+largeComponent",
+			args{
+				"1.200.70000",
+			},
+			[]int{1, 200, 70000},
+			false,
+		},
+		{
+			"-emptyComponent",
+			args{
+				"1..3",
+			},
+			nil,
+			true,
+		},
+		{
+			"-mixedSeparators",
+			args{
+				"1.2/3",
+			},
+			nil,
+			true,
+		},
+		{
+			"-negative",
+			args{
+				"1.-2",
+			},
+			nil,
+			true,
+		},
+		{
+			"-outOfRange",
+			args{
+				"1.4294967296",
+			},
+			nil,
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -3276,6 +3357,10 @@ func Test_parsePath(t *testing.T) {
 				t.Fatalf("parsePath() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
+			if err != nil && !errors.Is(err, ErrInvalidPath) {
+				t.Fatalf("parsePath() error = %v, want ErrInvalidPath", err)
+			}
+
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Fatalf("parsePath() = %s", diff)
 			}
@@ -0,0 +1,85 @@
+package ember_test
+
+import (
+	"fmt"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+// glowNode is a Glow root element collection holding the single node "1" named "R3LAYVirtualPatchBay".
+//
+//nolint:gochecknoglobals
+var glowNode = []byte{
+	0x60, 0x34, 0x6B, 0x32, 0xA0, 0x30, 0x63, 0x2E, 0xA0, 0x03, 0x02, 0x01, 0x01, 0xA1, 0x27, 0x31,
+	0x25, 0xA0, 0x16, 0x0C, 0x14, 0x52, 0x33, 0x4C, 0x41, 0x59, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61,
+	0x6C, 0x50, 0x61, 0x74, 0x63, 0x68, 0x42, 0x61, 0x79, 0xA1, 0x02, 0x0C, 0x00, 0xA4, 0x02, 0x0C,
+	0x00, 0xA3, 0x03, 0x01, 0x01, 0xFF,
+}
+
+func ExampleElementCollection_Populate() {
+	ec := ember.NewElementCollection()
+
+	err := ec.Populate(asn1.NewDecoder(glowNode))
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	el, err := ec.GetElementByPath("1")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(el.Identifier, el.ElementType, el.IsOnline)
+	// Output: R3LAYVirtualPatchBay node true
+}
+
+func ExampleElementCollection_MarshalJSON() {
+	ec := ember.NewElementCollection()
+
+	err := ec.Populate(asn1.NewDecoder(glowNode))
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	data, err := ec.MarshalJSON()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(string(data))
+	// Output: {"1":{"path":"1","element_type":"node","children":null,"identifier":"R3LAYVirtualPatchBay","description":"","is_online":true,"is_root":false}}
+}
+
+func ExampleGetRequestByType() {
+	request, err := ember.GetRequestByType(asn1.QualifiedNodeType, "1")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("request is %d bytes\n", len(request))
+	// Output: request is 76 bytes
+}
+
+func ExampleParse() {
+	ec, err := ember.Parse(glowNode)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	el, _ := ec.GetElementByPath("1")
+	fmt.Println(el.Identifier)
+	// Output: R3LAYVirtualPatchBay
+}
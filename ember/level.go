@@ -0,0 +1,97 @@
+package ember
+
+import (
+	"math"
+	"time"
+)
+
+// MeterScale describes how a device encodes meter levels in stream values.
+type MeterScale struct {
+	// Factor divides the raw value like the parameter factor, e.g. 32 for levels sent in 1/32 dB. Values below 2
+	// leave the raw value unchanged.
+	Factor int
+	// Linear is true if values are linear amplitudes with 1.0 at full scale, instead of decibels.
+	Linear bool
+}
+
+// Level converts a raw stream meter value to decibels relative to full scale. Whether the result is dBFS or dBTP
+// depends on what the meter measures, the conversion is the same.
+func (s MeterScale) Level(raw float64) float64 {
+	if s.Factor > 1 {
+		raw /= float64(s.Factor)
+	}
+
+	if s.Linear {
+		return LinearToDB(raw)
+	}
+
+	return raw
+}
+
+// Level reads a meter value from the octets of a stream entry and converts it to decibels using scale.
+func (d StreamDescriptor) Level(octets []byte, scale MeterScale) (float64, error) {
+	raw, err := d.Decode(octets)
+	if err != nil {
+		return 0, err
+	}
+
+	return scale.Level(raw), nil
+}
+
+// LinearToDB converts a linear amplitude to decibels, returning negative infinity for silence.
+func LinearToDB(amplitude float64) float64 {
+	return 20 * math.Log10(math.Abs(amplitude))
+}
+
+// DBToLinear converts decibels to a linear amplitude.
+func DBToLinear(db float64) float64 {
+	return math.Pow(10, db/20)
+}
+
+// Decay returns a level after falling with rate dB per second for the elapsed time.
+func Decay(level, rate float64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return level
+	}
+
+	return level - rate*elapsed.Seconds()
+}
+
+// PeakHold tracks the peak of a meter for display. A peak is held for Hold and then falls with DecayRate dB per
+// second until a higher level arrives. The zero value holds nothing and decays immediately.
+type PeakHold struct {
+	Hold      time.Duration
+	DecayRate float64
+
+	peak  float64
+	at    time.Time
+	valid bool
+}
+
+// Update feeds the current level measured at now and returns the peak to display.
+func (p *PeakHold) Update(level float64, now time.Time) float64 {
+	current := p.Peak(now)
+	if !p.valid || level >= current {
+		p.peak = level
+		p.at = now
+		p.valid = true
+
+		return level
+	}
+
+	return current
+}
+
+// Peak returns the displayed peak at now without feeding a new level, or negative infinity if nothing was fed yet.
+func (p *PeakHold) Peak(now time.Time) float64 {
+	if !p.valid {
+		return math.Inf(-1)
+	}
+
+	return Decay(p.peak, p.DecayRate, now.Sub(p.at)-p.Hold)
+}
+
+// Reset clears the held peak.
+func (p *PeakHold) Reset() {
+	*p = PeakHold{Hold: p.Hold, DecayRate: p.DecayRate}
+}
@@ -0,0 +1,91 @@
+package ember
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMeterScale_Level(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		scale MeterScale
+		raw   float64
+		want  float64
+	}{
+		{"+decibels", MeterScale{}, -18, -18},
+		{"+factor", MeterScale{Factor: 32}, -576, -18},
+		{"+linear", MeterScale{Linear: true}, 0.5, -6.0206},
+		{"+linearFactor", MeterScale{Factor: 100, Linear: true}, 100, 0},
+		{"+silence", MeterScale{Linear: true}, 0, math.Inf(-1)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.scale.Level(tt.raw), cmpopts.EquateApprox(0, 0.0001)); diff != "" {
+				t.Fatalf("MeterScale.Level() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamDescriptor_Level(t *testing.T) {
+	t.Parallel()
+
+	sd := StreamDescriptor{Format: StreamInt16BE, Offset: 2}
+
+	got, err := sd.Level([]byte{0x00, 0x00, 0xFD, 0xC0}, MeterScale{Factor: 32})
+	if err != nil {
+		t.Fatalf("StreamDescriptor.Level() error = %v", err)
+	}
+
+	if diff := cmp.Diff(-18.0, got); diff != "" {
+		t.Fatalf("StreamDescriptor.Level() = %s", diff)
+	}
+
+	_, err = sd.Level([]byte{0x00}, MeterScale{})
+	if err == nil {
+		t.Fatalf("StreamDescriptor.Level() expected error for short octets")
+	}
+}
+
+func TestPeakHold_Update(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1700000000, 0)
+	p := PeakHold{Hold: time.Second, DecayRate: 10}
+
+	steps := []struct {
+		name  string
+		level float64
+		after time.Duration
+		want  float64
+	}{
+		{"+first", -20, 0, -20},
+		{"+higher", -6, 100 * time.Millisecond, -6},
+		{"+held", -30, 900 * time.Millisecond, -6},
+		{"+decaying", -30, 1600 * time.Millisecond, -11},
+		{"+overtaken", -8, 1700 * time.Millisecond, -8},
+	}
+
+	for _, s := range steps {
+		got := p.Update(s.level, start.Add(s.after))
+		if diff := cmp.Diff(s.want, got, cmpopts.EquateApprox(0, 0.0001)); diff != "" {
+			t.Fatalf("PeakHold.Update() %s = %s", s.name, diff)
+		}
+	}
+
+	p.Reset()
+
+	if !math.IsInf(p.Peak(start), -1) {
+		t.Fatalf("PeakHold.Peak() after Reset() = %v", p.Peak(start))
+	}
+}
@@ -0,0 +1,128 @@
+package ember
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// ErrReservedTag error when an extra line protocol tag collides with a built-in tag.
+var ErrReservedTag = errors.New("reserved line protocol tag")
+
+//nolint:gochecknoglobals
+var (
+	// lineProtocolMeasurementEscaper escapes measurement names in InfluxDB line protocol.
+	lineProtocolMeasurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+	// lineProtocolTagEscaper escapes tag keys, tag values and field keys in InfluxDB line protocol.
+	lineProtocolTagEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+	// lineProtocolStringEscaper escapes string field values in InfluxDB line protocol.
+	lineProtocolStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// WriteLineProtocol writes the value of every parameter in the collection, including child parameters, as one
+// InfluxDB line protocol point, tagged with the parameters path and identifier plus the provided extra tags (e.g.
+// the device name). Extra tags named like the built-in tags are rejected. Parameters without a value are skipped.
+// Points are written in numeric path order.
+func (ec ElementCollection) WriteLineProtocol(w io.Writer, measurement string, tags map[string]string, ts time.Time) error {
+	for _, reserved := range []string{"path", "identifier"} {
+		if _, ok := tags[reserved]; ok {
+			return fmt.Errorf("%w: tag %q is set for every point", ErrReservedTag, reserved)
+		}
+	}
+
+	elements := ec.flatten()
+	extra := formatLineProtocolTags(tags)
+
+	for _, path := range sortedPaths(elements) {
+		el := elements[path]
+		if !isParameter(el) {
+			continue
+		}
+
+		field, ok := lineProtocolFieldValue(el.Value)
+		if !ok {
+			continue
+		}
+
+		var b strings.Builder
+
+		b.WriteString(lineProtocolMeasurementEscaper.Replace(measurement))
+		b.WriteString(extra)
+		b.WriteString(",path=")
+		b.WriteString(lineProtocolTagEscaper.Replace(path))
+
+		if el.Identifier != "" {
+			b.WriteString(",identifier=")
+			b.WriteString(lineProtocolTagEscaper.Replace(el.Identifier))
+		}
+
+		b.WriteString(" value=")
+		b.WriteString(field)
+		b.WriteString(" ")
+		b.WriteString(strconv.FormatInt(ts.UnixNano(), 10))
+		b.WriteString("\n")
+
+		_, err := io.WriteString(w, b.String())
+		if err != nil {
+			return fmt.Errorf("failed to write line protocol point for %q: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// isParameter returns true if the element is a parameter or qualified parameter.
+func isParameter(el *Element) bool {
+	return el.ElementType == asn1.ParameterType || el.ElementType == asn1.QualifiedParameterType
+}
+
+// formatLineProtocolTags returns the tags in key order, each preceded by a comma, ready to be appended to the
+// measurement.
+func formatLineProtocolTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+
+	for _, k := range keys {
+		if tags[k] == "" {
+			continue
+		}
+
+		b.WriteString(",")
+		b.WriteString(lineProtocolTagEscaper.Replace(k))
+		b.WriteString("=")
+		b.WriteString(lineProtocolTagEscaper.Replace(tags[k]))
+	}
+
+	return b.String()
+}
+
+// lineProtocolFieldValue formats a parameter value as line protocol field value, integers get the "i" suffix and
+// strings are quoted.
+func lineProtocolFieldValue(v any) (string, bool) {
+	switch val := v.(type) {
+	case int:
+		return strconv.Itoa(val) + "i", true
+	case int64:
+		return strconv.FormatInt(val, 10) + "i", true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	case string:
+		return `"` + lineProtocolStringEscaper.Replace(val) + `"`, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,121 @@
+package ember
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestElementCollection_WriteLineProtocol(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name        string
+		ec          ElementCollection
+		measurement string
+		tags        map[string]string
+		want        string
+		wantErr     bool
+	}{
+		{
+			"+empty",
+			ElementCollection{},
+			"ember",
+			nil,
+			"",
+			false,
+		},
+		{
+			"+children",
+			ElementCollection{
+				ElementKey{Path: "1.1"}: &Element{
+					Path:        "1.1",
+					ElementType: asn1.QualifiedNodeType,
+					Children: []*Element{
+						{Path: "2", ElementType: asn1.ParameterType, Identifier: "Corr Gain[dB]", Value: -6},
+						{Path: "1", ElementType: asn1.ParameterType, Identifier: "On", Value: true},
+						{Path: "3", ElementType: asn1.NodeType, Identifier: "Compressor"},
+					},
+				},
+			},
+			"ember levels",
+			map[string]string{"device": "mixer,1", "site": ""},
+			"ember\\ levels,device=mixer\\,1,path=1.1.1,identifier=On value=true 1700000000000000000\n" +
+				"ember\\ levels,device=mixer\\,1,path=1.1.2,identifier=Corr\\ Gain[dB] value=-6i 1700000000000000000\n",
+			false,
+		},
+		{
+			"+qualifiedParameters",
+			ElementCollection{
+				ElementKey{Path: "1.2", ID: "name"}: &Element{
+					Path:        "1.2",
+					ElementType: asn1.QualifiedParameterType,
+					Identifier:  "name",
+					Value:       `Studio "A"`,
+				},
+				ElementKey{Path: "1.3", ID: "level"}: &Element{
+					Path:        "1.3",
+					ElementType: asn1.QualifiedParameterType,
+					Identifier:  "level",
+					Value:       -12.5,
+				},
+				ElementKey{Path: "1.4", ID: "blob"}: &Element{
+					Path:        "1.4",
+					ElementType: asn1.QualifiedParameterType,
+					Identifier:  "blob",
+					Value:       []byte{0x01},
+				},
+			},
+			"ember",
+			nil,
+			"ember,path=1.2,identifier=name value=\"Studio \\\"A\\\"\" 1700000000000000000\n" +
+				"ember,path=1.3,identifier=level value=-12.5 1700000000000000000\n",
+			false,
+		},
+		{
+			"+numericPathOrder",
+			ElementCollection{
+				ElementKey{Path: "1.10"}: &Element{Path: "1.10", ElementType: asn1.QualifiedParameterType, Value: 10},
+				ElementKey{Path: "1.2"}:  &Element{Path: "1.2", ElementType: asn1.QualifiedParameterType, Value: 2},
+			},
+			"ember",
+			nil,
+			"ember,path=1.2 value=2i 1700000000000000000\n" +
+				"ember,path=1.10 value=10i 1700000000000000000\n",
+			false,
+		},
+		{
+			"-reservedTag",
+			ElementCollection{
+				ElementKey{Path: "1.2"}: &Element{Path: "1.2", ElementType: asn1.QualifiedParameterType, Value: 2},
+			},
+			"ember",
+			map[string]string{"path": "override"},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+
+			err := tt.ec.WriteLineProtocol(&b, tt.measurement, tt.tags, ts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ElementCollection.WriteLineProtocol() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Fatalf("ElementCollection.WriteLineProtocol() = %s", diff)
+			}
+		})
+	}
+}
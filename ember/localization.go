@@ -0,0 +1,78 @@
+package ember
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Translation holds the localized labels of a single element, empty fields keep the label provided by the device.
+type Translation struct {
+	Identifier  string `json:"identifier,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Localization maps element paths to translations of their identifier and description, as device provided
+// identifiers are often cryptic English abbreviations.
+type Localization map[string]Translation
+
+// LoadLocalization reads a localization overlay from JSON in the form {"1.1.2": {"identifier": "Verstärkung",
+// "description": "Eingangsverstärkung in dB"}}. Paths are validated while loading.
+func LoadLocalization(r io.Reader) (Localization, error) {
+	var loc Localization
+
+	err := json.NewDecoder(r).Decode(&loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode localization: %w", err)
+	}
+
+	for path := range loc {
+		_, err = ParsePath(path)
+		if err != nil {
+			return nil, fmt.Errorf("localization: %w", err)
+		}
+	}
+
+	return loc, nil
+}
+
+// Localize returns a copy of the collection with identifiers and descriptions replaced by their translations. The
+// collection itself is not modified.
+func (ec ElementCollection) Localize(loc Localization) ElementCollection {
+	out := make(ElementCollection, len(ec))
+
+	for key, el := range ec {
+		localized := loc.apply(key.Path, el)
+
+		if len(el.Children) > 0 {
+			localized.Children = make([]*Element, len(el.Children))
+			for i, ch := range el.Children {
+				localized.Children[i] = loc.apply(key.Path+"."+ch.Path, ch)
+			}
+		}
+
+		out[key] = localized
+	}
+
+	return out
+}
+
+// apply returns a copy of el with the translation for path applied.
+func (loc Localization) apply(path string, el *Element) *Element {
+	localized := *el
+
+	t, ok := loc[path]
+	if !ok {
+		return &localized
+	}
+
+	if t.Identifier != "" {
+		localized.Identifier = t.Identifier
+	}
+
+	if t.Description != "" {
+		localized.Description = t.Description
+	}
+
+	return &localized
+}
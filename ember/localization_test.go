@@ -0,0 +1,80 @@
+package ember
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestLoadLocalization(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    Localization
+		wantErr bool
+	}{
+		{
+			"+valid",
+			`{"1.2": {"identifier": "Verstärkung", "description": "Eingang"}}`,
+			Localization{"1.2": {Identifier: "Verstärkung", Description: "Eingang"}},
+			false,
+		},
+		{"-invalidPath", `{"1..2": {"identifier": "x"}}`, nil, true},
+		{"-invalidJSON", `[]`, nil, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadLocalization(strings.NewReader(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLocalization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("LoadLocalization() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestElementCollection_Localize(t *testing.T) {
+	t.Parallel()
+
+	ec := ElementCollection{
+		ElementKey{Path: "1"}: &Element{
+			Path:        "1",
+			ElementType: asn1.QualifiedNodeType,
+			Identifier:  "CH1",
+			Children:    []*Element{{Path: "2", ElementType: asn1.ParameterType, Identifier: "Gn", Description: "gain"}},
+		},
+	}
+
+	got := ec.Localize(Localization{"1": {Description: "Kanal 1"}, "1.2": {Identifier: "Verstärkung"}})
+
+	want := ElementCollection{
+		ElementKey{Path: "1"}: &Element{
+			Path:        "1",
+			ElementType: asn1.QualifiedNodeType,
+			Identifier:  "CH1",
+			Description: "Kanal 1",
+			Children: []*Element{
+				{Path: "2", ElementType: asn1.ParameterType, Identifier: "Verstärkung", Description: "gain"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("ElementCollection.Localize() = %s", diff)
+	}
+
+	if ec[ElementKey{Path: "1"}].Children[0].Identifier != "Gn" {
+		t.Fatalf("ElementCollection.Localize() modified the collection")
+	}
+}
@@ -0,0 +1,110 @@
+package ember
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldNaming defines how field names of nodes, parameters and functions are written in JSON exports.
+type FieldNaming int
+
+const (
+	// SnakeCase writes field names like "element_type", as MarshalJSON does.
+	SnakeCase FieldNaming = iota
+	// CamelCase writes field names like "elementType", for consumers expecting JavaScript style names.
+	CamelCase
+)
+
+// MarshalOptions configures JSON exports of a collection. The zero value produces the same output as MarshalJSON.
+type MarshalOptions struct {
+	// FieldNaming defines the naming of element fields. Collection keys (paths) are never renamed.
+	FieldNaming FieldNaming
+	// Localization replaces identifiers and descriptions with their translations, if set.
+	Localization Localization
+}
+
+// MarshalJSONWithOptions works like MarshalJSON, applying the provided options to the output.
+func (ec ElementCollection) MarshalJSONWithOptions(opts MarshalOptions) ([]byte, error) {
+	if len(opts.Localization) > 0 {
+		ec = ec.Localize(opts.Localization)
+	}
+
+	data, err := ec.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.FieldNaming == SnakeCase {
+		return data, nil
+	}
+
+	var out map[string]any
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	err = dec.Decode(&out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode native marshal: %w", err)
+	}
+
+	for path, v := range out {
+		out[path] = renameFields(v, camelCase)
+	}
+
+	data, err = json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed renamed marshal: %w", err)
+	}
+
+	return data, nil
+}
+
+// renameFields renames the keys of all objects in v, including nested children.
+func renameFields(v any, rename func(string) string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			out[rename(k)] = renameFields(val, rename)
+		}
+
+		return out
+	case []any:
+		for i, val := range t {
+			t[i] = renameFields(val, rename)
+		}
+
+		return t
+	default:
+		return v
+	}
+}
+
+// camelCase converts snake case and Go style field names to camel case, e.g. "is_online" and "IsOnline" to "isOnline".
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+
+	var b strings.Builder
+
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(p)
+		if i == 0 {
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+
+		b.WriteString(p[size:])
+	}
+
+	return b.String()
+}
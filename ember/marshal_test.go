@@ -0,0 +1,111 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestElementCollection_MarshalJSONWithOptions(t *testing.T) {
+	t.Parallel()
+
+	ec := ElementCollection{
+		ElementKey{Path: "1", ID: "router"}: &Element{
+			Path:        "1",
+			ElementType: asn1.QualifiedNodeType,
+			Identifier:  "router",
+			IsOnline:    true,
+			Children:    []*Element{{Path: "2", ElementType: asn1.ParameterType, Identifier: "gain", Value: 5}},
+		},
+		ElementKey{Path: "1.3", ID: "level"}: &Element{
+			Path:        "1.3",
+			ElementType: asn1.QualifiedParameterType,
+			Identifier:  "level",
+			Value:       -12.5,
+			IsOnline:    true,
+			ValueType:   2,
+		},
+	}
+
+	snake, err := ec.MarshalJSON()
+	if err != nil {
+		t.Fatalf("ElementCollection.MarshalJSON() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts MarshalOptions
+		want string
+	}{
+		{
+			"+default",
+			MarshalOptions{},
+			string(snake),
+		},
+		{
+			"+camelCase",
+			MarshalOptions{FieldNaming: CamelCase},
+			`{"1":{"children":[{"access":0,"children":null,"default":null,"description":"","elementType":"parameter",` +
+				`"enumeration":"","factor":0,"format":"","identifier":"gain","isOnline":false,"isRoot":false,` +
+				`"maximum":null,"minimum":null,"path":"2","value":5,"valueType":0}],"description":"",` +
+				`"elementType":"qualified_node","identifier":"router","isOnline":true,"isRoot":false,"path":"1"},` +
+				`"1.3":{"elementType":"qualified_parameter","identifier":"level","isOnline":true,"path":"1.3",` +
+				`"type":2,"value":-12.5}}`,
+		},
+		{
+			"+localized",
+			MarshalOptions{Localization: Localization{
+				"1.2": {Identifier: "Verstärkung"},
+				"1.3": {Identifier: "Pegel", Description: "Ausgangspegel"},
+			}},
+			`{"1":{"path":"1","element_type":"qualified_node","children":[{"Path":"2","ElementType":"parameter",` +
+				`"Identifier":"Verstärkung","Description":"","Children":null,"IsOnline":false,"IsRoot":false,` +
+				`"Maximum":null,"Minimum":null,"Value":5,"Access":0,"Format":"","Enumeration":"","Factor":0,` +
+				`"Default":null,"ValueType":0}],"identifier":"router","description":"","is_online":true,"is_root":false},` +
+				`"1.3":{"path":"1.3","element_type":"qualified_parameter","identifier":"Pegel",` +
+				`"description":"Ausgangspegel","value":-12.5,"is_online":true,"type":2}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ec.MarshalJSONWithOptions(tt.opts)
+			if err != nil {
+				t.Fatalf("ElementCollection.MarshalJSONWithOptions() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Fatalf("ElementCollection.MarshalJSONWithOptions() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"+snake", "element_type", "elementType"},
+		{"+single", "path", "path"},
+		{"+goStyle", "IsOnline", "isOnline"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, camelCase(tt.in)); diff != "" {
+				t.Fatalf("camelCase() = %s", diff)
+			}
+		})
+	}
+}
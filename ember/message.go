@@ -0,0 +1,53 @@
+package ember
+
+import (
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// MessageKind tells the kinds of glow messages apart, e.g. to route unsolicited messages.
+type MessageKind int
+
+const (
+	// MessageError is a message that is not valid glow.
+	MessageError MessageKind = iota
+	// MessageTree is a root element collection, e.g. a directory response or an unsolicited element update.
+	MessageTree
+	// MessageStream is a stream collection holding stream updates.
+	MessageStream
+	// MessageInvocationResult is the result of a function invocation.
+	MessageInvocationResult
+)
+
+// String returns the name of the message kind.
+func (k MessageKind) String() string {
+	switch k {
+	case MessageTree:
+		return "tree"
+	case MessageStream:
+		return "stream"
+	case MessageInvocationResult:
+		return "invocation result"
+	default:
+		return "error"
+	}
+}
+
+// ClassifyMessage returns the kind of a glow message by looking at its root, without decoding it.
+func ClassifyMessage(glow []byte) MessageKind {
+	tag, root, _, err := asn1.Next(glow)
+	if err != nil || tag != asn1.ApplicationByte(asn1.RootElementCollectionTag) || len(root) == 0 ||
+		!asn1.IsApplication(root[0]) {
+		return MessageError
+	}
+
+	switch asn1.Tag(asn1.TagNumber(root[0])) {
+	case asn1.TagRootElementCollection:
+		return MessageTree
+	case asn1.TagStreamCollection:
+		return MessageStream
+	case asn1.TagInvocationResult:
+		return MessageInvocationResult
+	default:
+		return MessageError
+	}
+}
@@ -0,0 +1,45 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClassifyMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		glow []byte
+		want MessageKind
+	}{
+		{"+tree", parseGlow, MessageTree},
+		{"+treeIndefinite", []byte{0x60, 0x80, 0x6B, 0x80, 0x00, 0x00, 0x00, 0x00}, MessageTree},
+		{"+stream", []byte{0x60, 0x02, 0x66, 0x00}, MessageStream},
+		{"+invocationResult", []byte{0x60, 0x07, 0x77, 0x05, 0xA0, 0x03, 0x02, 0x01, 0x01}, MessageInvocationResult},
+		{"-empty", nil, MessageError},
+		{"-truncated", []byte{0x60, 0x05, 0x6B}, MessageError},
+		{"-notRoot", []byte{0x6B, 0x00}, MessageError},
+		{"-contextTag", []byte{0x60, 0x02, 0xAB, 0x00}, MessageError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, ClassifyMessage(tt.glow)); diff != "" {
+				t.Fatalf("ClassifyMessage() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestMessageKind_String(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff("invocation result", MessageInvocationResult.String()); diff != "" {
+		t.Fatalf("MessageKind.String() = %s", diff)
+	}
+}
@@ -0,0 +1,162 @@
+package ember
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// PanelHint overrides how a node or parameter is shown in a panel export.
+type PanelHint struct {
+	// Title replaces the identifier of the element as label.
+	Title string `json:"title,omitempty"`
+	// Order places the element relative to its siblings, lower first. Elements without order follow in tree order.
+	Order int `json:"order,omitempty"`
+	// Hidden drops the element from the export, for nodes the whole panel is dropped.
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// PanelOverlay holds hints for panel exports keyed by element path.
+type PanelOverlay map[string]PanelHint
+
+// PanelItem is a single parameter shown in a panel.
+type PanelItem struct {
+	Path        string `json:"path"`
+	Label       string `json:"label"`
+	Order       int    `json:"order"`
+	Access      int    `json:"access,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Enumeration string `json:"enumeration,omitempty"`
+	Minimum     any    `json:"minimum,omitempty"`
+	Maximum     any    `json:"maximum,omitempty"`
+}
+
+// Panel groups the parameters, which are direct children of a node, for dashboard generators.
+type Panel struct {
+	Path  string      `json:"path"`
+	Title string      `json:"title"`
+	Order int         `json:"order"`
+	Items []PanelItem `json:"items"`
+}
+
+// LoadPanelOverlay reads panel hints from JSON in the form {"1.1": {"title": "Channel 1", "order": 1}, "1.1.3":
+// {"hidden": true}}. Paths are validated while loading.
+func LoadPanelOverlay(r io.Reader) (PanelOverlay, error) {
+	var overlay PanelOverlay
+
+	err := json.NewDecoder(r).Decode(&overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode panel overlay: %w", err)
+	}
+
+	for path := range overlay {
+		_, err = ParsePath(path)
+		if err != nil {
+			return nil, fmt.Errorf("panel overlay: %w", err)
+		}
+	}
+
+	return overlay, nil
+}
+
+// Panels groups all parameters of the collection by their parent node into panels, in the order dashboards should
+// show them. Panels and items are ordered by the order hints of overlay first and tree order second, their order
+// fields are renumbered from 1. Titles default to the node identifier, or the node path if the node itself is not
+// part of the collection. Overlay may be nil.
+func (ec ElementCollection) Panels(overlay PanelOverlay) []Panel {
+	elements := ec.flatten()
+	panels := make(map[string]*Panel)
+
+	for path, el := range elements {
+		if baseType(el.ElementType) != asn1.ParameterType || overlay[path].Hidden {
+			continue
+		}
+
+		parent := parentPath(path)
+		if overlay[parent].Hidden {
+			continue
+		}
+
+		p, ok := panels[parent]
+		if !ok {
+			p = &Panel{Path: parent, Title: panelLabel(parent, elements[parent], overlay), Order: overlay[parent].Order}
+			panels[parent] = p
+		}
+
+		p.Items = append(p.Items, PanelItem{
+			Path:        path,
+			Label:       panelLabel(path, el, overlay),
+			Order:       overlay[path].Order,
+			Access:      el.Access,
+			Format:      el.Format,
+			Enumeration: el.Enumeration,
+			Minimum:     el.Minimum,
+			Maximum:     el.Maximum,
+		})
+	}
+
+	out := make([]Panel, 0, len(panels))
+
+	for _, p := range panels {
+		sort.Slice(p.Items, func(i, j int) bool {
+			return lessHinted(p.Items[i].Order, p.Items[i].Path, p.Items[j].Order, p.Items[j].Path)
+		})
+
+		for i := range p.Items {
+			p.Items[i].Order = i + 1
+		}
+
+		out = append(out, *p)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return lessHinted(out[i].Order, out[i].Path, out[j].Order, out[j].Path)
+	})
+
+	for i := range out {
+		out[i].Order = i + 1
+	}
+
+	return out
+}
+
+// panelLabel returns the overlay title, the identifier or the path of the element, whichever is set first.
+func panelLabel(path string, el *Element, overlay PanelOverlay) string {
+	if title := overlay[path].Title; title != "" {
+		return title
+	}
+
+	if el != nil && el.Identifier != "" {
+		return el.Identifier
+	}
+
+	return path
+}
+
+// parentPath returns the path of the parent of the element at path, or "" for top level elements.
+func parentPath(path string) string {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return ""
+	}
+
+	return path[:i]
+}
+
+// lessHinted orders elements with an order hint before those without, by hint, then by numeric path order.
+func lessHinted(orderA int, pathA string, orderB int, pathB string) bool {
+	switch {
+	case orderA != 0 && orderB == 0:
+		return true
+	case orderA == 0 && orderB != 0:
+		return false
+	case orderA != orderB:
+		return orderA < orderB
+	default:
+		return lessPath(pathA, pathB)
+	}
+}
@@ -0,0 +1,119 @@
+package ember
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestElementCollection_Panels(t *testing.T) {
+	t.Parallel()
+
+	ec := ElementCollection{
+		ElementKey{Path: "1.1"}: &Element{
+			Path:        "1.1",
+			ElementType: asn1.QualifiedNodeType,
+			Identifier:  "CH1",
+			Children: []*Element{
+				{Path: "10", ElementType: asn1.ParameterType, Identifier: "Mute", Access: 3},
+				{Path: "2", ElementType: asn1.ParameterType, Identifier: "Gain", Access: 3, Format: "%.1f dB"},
+				{Path: "3", ElementType: asn1.NodeType, Identifier: "EQ"},
+			},
+		},
+		ElementKey{Path: "1.2.1", ID: "on"}: &Element{
+			Path:        "1.2.1",
+			ElementType: asn1.QualifiedParameterType,
+			Identifier:  "on",
+			Access:      1,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		overlay PanelOverlay
+		want    []Panel
+	}{
+		{
+			"+treeOrder",
+			nil,
+			[]Panel{
+				{Path: "1.1", Title: "CH1", Order: 1, Items: []PanelItem{
+					{Path: "1.1.2", Label: "Gain", Order: 1, Access: 3, Format: "%.1f dB"},
+					{Path: "1.1.10", Label: "Mute", Order: 2, Access: 3},
+				}},
+				{Path: "1.2", Title: "1.2", Order: 2, Items: []PanelItem{
+					{Path: "1.2.1", Label: "on", Order: 1, Access: 1},
+				}},
+			},
+		},
+		{
+			"+overlay",
+			PanelOverlay{
+				"1.2":    {Title: "Monitor", Order: 1},
+				"1.1.10": {Title: "Mute Channel", Order: 1},
+				"1.1.2":  {Hidden: true},
+			},
+			[]Panel{
+				{Path: "1.2", Title: "Monitor", Order: 1, Items: []PanelItem{
+					{Path: "1.2.1", Label: "on", Order: 1, Access: 1},
+				}},
+				{Path: "1.1", Title: "CH1", Order: 2, Items: []PanelItem{
+					{Path: "1.1.10", Label: "Mute Channel", Order: 1, Access: 3},
+				}},
+			},
+		},
+		{
+			"+hiddenPanel",
+			PanelOverlay{"1.1": {Hidden: true}},
+			[]Panel{
+				{Path: "1.2", Title: "1.2", Order: 1, Items: []PanelItem{
+					{Path: "1.2.1", Label: "on", Order: 1, Access: 1},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, ec.Panels(tt.overlay)); diff != "" {
+				t.Fatalf("ElementCollection.Panels() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadPanelOverlay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    PanelOverlay
+		wantErr bool
+	}{
+		{"+valid", `{"1.1": {"title": "CH1", "order": 2}}`, PanelOverlay{"1.1": {Title: "CH1", Order: 2}}, false},
+		{"-invalidPath", `{"1.x": {"hidden": true}}`, nil, true},
+		{"-invalidJSON", `{`, nil, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadPanelOverlay(strings.NewReader(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPanelOverlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("LoadPanelOverlay() = %s", diff)
+			}
+		})
+	}
+}
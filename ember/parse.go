@@ -0,0 +1,46 @@
+package ember
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+// Parse decodes a glow payload into a new collection. Panics raised by malformed data are returned as errors, see
+// SafePopulate.
+func Parse(glow []byte) (ElementCollection, error) {
+	ec := NewElementCollection()
+
+	err := ec.SafePopulate(asn1.NewDecoder(glow))
+	if err != nil {
+		return nil, err
+	}
+
+	return ec, nil
+}
+
+// ParseS101 decodes raw S101 frames, e.g. as captured from the wire, into a new collection. The frames of a multi
+// packet message are joined, incomplete frames are an error.
+func ParseS101(frames []byte) (ElementCollection, error) {
+	s101s, incomplete, err := s101.GetS101s(frames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get s101 frames: %w", err)
+	}
+
+	if len(incomplete) > 0 {
+		return nil, fmt.Errorf("failed to get s101 frames: %d bytes of incomplete frame", len(incomplete))
+	}
+
+	if len(s101s) == 0 {
+		return nil, errors.New("failed to get s101 frames: no frame found")
+	}
+
+	glow, _, err := s101.SafeDecode(s101s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode s101 frames: %w", err)
+	}
+
+	return Parse(glow)
+}
@@ -0,0 +1,66 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+// parseGlow is a glow root element collection holding the single node "1" named "R3LAYVirtualPatchBay".
+//
+//nolint:gochecknoglobals
+var parseGlow = []byte{
+	0x60, 0x34, 0x6B, 0x32, 0xA0, 0x30, 0x63, 0x2E, 0xA0, 0x03, 0x02, 0x01, 0x01, 0xA1, 0x27, 0x31,
+	0x25, 0xA0, 0x16, 0x0C, 0x14, 0x52, 0x33, 0x4C, 0x41, 0x59, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61,
+	0x6C, 0x50, 0x61, 0x74, 0x63, 0x68, 0x42, 0x61, 0x79, 0xA1, 0x02, 0x0C, 0x00, 0xA4, 0x02, 0x0C,
+	0x00, 0xA3, 0x03, 0x01, 0x01, 0xFF,
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	want := ElementCollection{
+		ElementKey{ID: "R3LAYVirtualPatchBay", Path: "1"}: &Element{
+			Path:        "1",
+			ElementType: asn1.NodeType,
+			Identifier:  "R3LAYVirtualPatchBay",
+			IsOnline:    true,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		parse   func() (ElementCollection, error)
+		want    ElementCollection
+		wantErr bool
+	}{
+		{"+glow", func() (ElementCollection, error) { return Parse(parseGlow) }, want, false},
+		{
+			"+s101",
+			func() (ElementCollection, error) { return ParseS101(s101.Encode(parseGlow, s101.SinglePacket)) },
+			want,
+			false,
+		},
+		{"-glowTruncated", func() (ElementCollection, error) { return Parse(parseGlow[:20]) }, nil, true},
+		{"-s101Incomplete", func() (ElementCollection, error) { return ParseS101([]byte{0xFE, 0x00, 0x0E}) }, nil, true},
+		{"-s101Empty", func() (ElementCollection, error) { return ParseS101(nil) }, nil, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("Parse() = %s", diff)
+			}
+		})
+	}
+}
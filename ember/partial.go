@@ -0,0 +1,193 @@
+package ember
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// ElementError describes a top level element of a payload which could not be decoded.
+type ElementError struct {
+	// Path of the element, as far as it could be read, empty if not even the path could be decoded.
+	Path string
+	// Offset of the element in the glow payload.
+	Offset int
+	Err    error
+}
+
+// Error returns the element path, offset and cause.
+func (e ElementError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "unknown path"
+	}
+
+	return fmt.Sprintf("element %s at offset %d: %v", path, e.Offset, e.Err)
+}
+
+// Unwrap returns the cause.
+func (e ElementError) Unwrap() error {
+	return e.Err
+}
+
+// PopulateErrors lists the elements PopulatePartial failed to decode, in payload order.
+type PopulateErrors []ElementError
+
+// Error lists all failed elements with their error.
+func (pe PopulateErrors) Error() string {
+	msgs := make([]string, 0, len(pe))
+	for _, e := range pe {
+		msgs = append(msgs, e.Error())
+	}
+
+	return fmt.Sprintf("failed to decode %d element(s): %s", len(pe), strings.Join(msgs, "; "))
+}
+
+// PopulatePartial works like Populate on a glow payload, but decodes every top level element on its own. Elements
+// failing to decode are skipped and returned as PopulateErrors error, while all other elements are added to the
+// collection. If the payload is corrupt beyond an element, the elements up to it are kept and decoding stops there.
+// Any other error means the root of the payload could not be read and nothing was added.
+func (ec ElementCollection) PopulatePartial(glow []byte) error {
+	root, err := openEnvelope(glow, asn1.ApplicationByte(asn1.RootElementCollectionTag))
+	if err != nil {
+		return fmt.Errorf("failed to read element root collection: %w", err)
+	}
+
+	elements, err := openEnvelope(root, asn1.ApplicationByte(asn1.RootElementTag))
+	if err != nil {
+		return fmt.Errorf("failed to read element tag: %w", err)
+	}
+
+	var errs PopulateErrors
+
+	for len(elements) > 0 && elements[0] != endOfContents {
+		offset := cap(glow) - cap(elements)
+
+		_, _, rest, err := asn1.Next(elements)
+		if err != nil {
+			errs = append(errs, ElementError{Offset: offset, Err: err})
+
+			break
+		}
+
+		tlv := elements[:len(elements)-len(rest)]
+		elements = rest
+
+		err = ec.populateElement(tlv)
+		if err != nil {
+			errs = append(errs, ElementError{Path: elementPath(tlv), Offset: offset, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// endOfContents is the first byte of the end of an indefinite length sequence.
+const endOfContents = 0x00
+
+// openEnvelope checks the tag of the sequence at the start of data and returns its content, without validating it,
+// so a corrupt element inside does not hide the elements before it. Content of indefinite length runs to the end of
+// data, including the closing bytes of the sequence and its parents.
+func openEnvelope(data []byte, tag byte) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, errors.New("unexpected end of data")
+	}
+
+	if data[0] != tag {
+		return nil, fmt.Errorf("unexpected tag 0x%02X", data[0])
+	}
+
+	first := data[1]
+	data = data[2:]
+
+	if first == 0x80 {
+		return data, nil
+	}
+
+	length := int(first)
+
+	if first > 0x80 {
+		n := int(first &^ 0x80)
+		if n > len(data) || n > 4 {
+			return nil, errors.New("invalid length")
+		}
+
+		length = 0
+		for _, b := range data[:n] {
+			length = length<<8 | int(b)
+		}
+
+		data = data[n:]
+	}
+
+	return data[:min(length, len(data))], nil
+}
+
+// populateElement decodes a single top level element, wrapped into its own root, and adds it to the collection.
+func (ec ElementCollection) populateElement(tlv []byte) error {
+	wrapped := make([]byte, 0, len(tlv)+8)
+	wrapped = append(wrapped, asn1.ApplicationByte(asn1.RootElementCollectionTag), 0x80)
+	wrapped = append(wrapped, asn1.ApplicationByte(asn1.RootElementTag), 0x80)
+	wrapped = append(wrapped, tlv...)
+	wrapped = append(wrapped, 0x00, 0x00, 0x00, 0x00)
+
+	single := NewElementCollection()
+
+	err := single.SafePopulate(asn1.NewDecoder(wrapped))
+	if err != nil {
+		return err
+	}
+
+	for k, el := range single {
+		ec[k] = el
+	}
+
+	return nil
+}
+
+// elementPath returns the path of a top level element from its first field, or empty if it can not be read. Qualified
+// elements hold a relative OID, all others their number.
+func elementPath(tlv []byte) string {
+	_, app, _, err := asn1.Next(tlv)
+	if err != nil {
+		return ""
+	}
+
+	_, fields, _, err := asn1.Next(app)
+	if err != nil {
+		return ""
+	}
+
+	tag, field, _, err := asn1.Next(fields)
+	if err != nil || tag != asn1.ContextByte(asn1.ContextZeroTag) {
+		return ""
+	}
+
+	if len(field) > 0 && field[0] == asn1.UniversalObjectTag {
+		path, err := asn1.NewDecoder(field).DecodeUniversal()
+		if err != nil {
+			return ""
+		}
+
+		parts := make([]string, 0, len(path))
+		for _, p := range path {
+			parts = append(parts, strconv.Itoa(p))
+		}
+
+		return strings.Join(parts, ".")
+	}
+
+	number, err := asn1.NewDecoder(field).DecodeInteger()
+	if err != nil {
+		return ""
+	}
+
+	return strconv.Itoa(number)
+}
@@ -0,0 +1,83 @@
+package ember
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// partialGlow wraps the elements into an indefinite length root element collection.
+func partialGlow(elements ...[]byte) []byte {
+	out := []byte{0x60, 0x80, 0x6B, 0x80}
+	for _, el := range elements {
+		out = append(out, el...)
+	}
+
+	return append(out, 0x00, 0x00, 0x00, 0x00)
+}
+
+func TestElementCollection_PopulatePartial(t *testing.T) {
+	t.Parallel()
+
+	good := parseGlow[4:]
+	// unknownType is a Target at path 1.2.3, which is not allowed at the top level.
+	unknownType := []byte{0xA0, 0x09, 0x6E, 0x07, 0xA0, 0x05, 0x0D, 0x03, 0x01, 0x02, 0x03}
+	// noPath is a Parameter whose number is not an integer.
+	noPath := []byte{0xA0, 0x05, 0x61, 0x03, 0xA0, 0x01, 0x00}
+	// truncated claims more content than follows.
+	truncated := []byte{0xA0, 0x20, 0x63}
+
+	tests := []struct {
+		name     string
+		glow     []byte
+		wantLen  int
+		wantErrs []ElementError
+		wantErr  bool
+	}{
+		{"+complete", parseGlow, 1, nil, false},
+		{"+indefinite", partialGlow(good), 1, nil, false},
+		{"+empty", partialGlow(), 0, nil, false},
+		{"-unknownType", partialGlow(unknownType, good), 1, []ElementError{{Path: "1.2.3", Offset: 4}}, true},
+		{"-noPath", partialGlow(good, noPath), 1, []ElementError{{Offset: 54}}, true},
+		{"-truncated", append(partialGlow(good)[:54], truncated...), 1, []ElementError{{Offset: 54}}, true},
+		{"-badRoot", []byte{0x6B, 0x80, 0x00, 0x00}, 0, nil, true},
+		{"-empty", nil, 0, nil, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ec := NewElementCollection()
+
+			err := ec.PopulatePartial(tt.glow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ElementCollection.PopulatePartial() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantLen, len(ec)); diff != "" {
+				t.Fatalf("ElementCollection.PopulatePartial() elements = %s", diff)
+			}
+
+			var errs PopulateErrors
+			if !errors.As(err, &errs) {
+				errs = nil
+			}
+
+			var got []ElementError
+			for _, e := range errs {
+				if e.Err == nil {
+					t.Fatalf("ElementCollection.PopulatePartial() element error without cause: %v", e)
+				}
+
+				got = append(got, ElementError{Path: e.Path, Offset: e.Offset})
+			}
+
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Fatalf("ElementCollection.PopulatePartial() errors = %s", diff)
+			}
+		})
+	}
+}
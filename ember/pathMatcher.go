@@ -0,0 +1,69 @@
+package ember
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathMatcher is a path parsed once, e.g. per request or subscription, which can then be compared against the paths
+// of incoming elements without parsing or allocating.
+type PathMatcher struct {
+	oid  []int
+	text string
+}
+
+// CompilePath parses path, accepting the same notations as ParsePath, into a PathMatcher.
+func CompilePath(path string) (PathMatcher, error) {
+	oid, err := parsePath(path)
+	if err != nil {
+		return PathMatcher{}, err
+	}
+
+	parts := make([]string, 0, len(oid))
+	for _, p := range oid {
+		parts = append(parts, strconv.Itoa(p))
+	}
+
+	return PathMatcher{oid: oid, text: strings.Join(parts, ".")}, nil
+}
+
+// OID returns the parsed path components.
+func (m PathMatcher) OID() []int {
+	return m.oid
+}
+
+// String returns the path in the dotted notation used as collection key.
+func (m PathMatcher) String() string {
+	return m.text
+}
+
+// Match returns true if path, in dotted notation, is the compiled path.
+func (m PathMatcher) Match(path string) bool {
+	return m.text == path
+}
+
+// MatchChild returns true if the child path below parent, both in dotted notation, is the compiled path.
+func (m PathMatcher) MatchChild(parent, child string) bool {
+	return len(m.text) == len(parent)+1+len(child) &&
+		strings.HasPrefix(m.text, parent) &&
+		m.text[len(parent)] == '.' &&
+		strings.HasSuffix(m.text, child)
+}
+
+// GetElementByMatcher returns the element of the collection, or a direct child of one, matching the compiled path.
+func (ec ElementCollection) GetElementByMatcher(m PathMatcher) (*Element, error) {
+	for key, el := range ec {
+		if m.Match(key.Path) {
+			return el, nil
+		}
+
+		for _, ch := range el.Children {
+			if m.MatchChild(key.Path, ch.Path) {
+				return ch, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find element with path %q: %w", m.text, ErrElementNotFound)
+}
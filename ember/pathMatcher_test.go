@@ -0,0 +1,100 @@
+package ember
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestPathMatcher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		path      string
+		match     string
+		parent    string
+		child     string
+		wantMatch bool
+		wantChild bool
+		wantErr   bool
+	}{
+		{"+dotted", "1.2.3", "1.2.3", "1.2", "3", true, true, false},
+		{"+slashes", " 1/2/3 ", "1.2.3", "1", "2.3", true, true, false},
+		{"+noMatch", "1.2.3", "1.2.30", "1.2", "30", false, false, false},
+		{"+prefixOnly", "1.2.3", "1.2", "1.23", "", false, false, false},
+		{"-invalid", "1.x", "", "", "", false, false, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := CompilePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompilePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tt.wantMatch, m.Match(tt.match)); diff != "" {
+				t.Fatalf("PathMatcher.Match() = %s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantChild, m.MatchChild(tt.parent, tt.child)); diff != "" {
+				t.Fatalf("PathMatcher.MatchChild() = %s", diff)
+			}
+		})
+	}
+}
+
+// meterCollection returns a node holding n meter parameters, as received from a metering stream.
+func meterCollection(n int) ElementCollection {
+	node := &Element{Path: "1.4", ElementType: asn1.QualifiedNodeType, Identifier: "meters"}
+	for i := 1; i <= n; i++ {
+		node.Children = append(node.Children, &Element{
+			Path:        fmt.Sprint(i),
+			ElementType: asn1.ParameterType,
+			Identifier:  fmt.Sprintf("meter%d", i),
+			Value:       -20,
+		})
+	}
+
+	return ElementCollection{ElementKey{Path: node.Path, ID: node.Identifier}: node}
+}
+
+func BenchmarkElementCollection_GetElementByPath(b *testing.B) {
+	ec := meterCollection(64)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := ec.GetElementByPath("1.4.64")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkElementCollection_GetElementByMatcher(b *testing.B) {
+	ec := meterCollection(64)
+
+	m, err := CompilePath("1/4/64")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err = ec.GetElementByMatcher(m)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package ember
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// QuirksProfile rewrites the glow payload of devices deviating from the usual Ember+ encoding before decoding.
+type QuirksProfile struct {
+	// Name identifies the profile, e.g. in logs when onboarding a new device type.
+	Name string
+	// Rewrite returns the payload to decode, nil decodes the payload unchanged.
+	Rewrite func(glow []byte) ([]byte, error)
+}
+
+// DefaultQuirksProfiles returns the known quirks profiles in the order they are tried: the payload as is, all
+// lengths re-encoded as definite lengths, and missing closing bytes appended before re-encoding all lengths as
+// definite lengths. Missing closing bytes alone are already tolerated by the standard decoder.
+func DefaultQuirksProfiles() []QuirksProfile {
+	return []QuirksProfile{
+		{Name: "standard"},
+		{Name: "definite-length", Rewrite: asn1.ToDefinite},
+		{Name: "missing-terminators", Rewrite: closeAndDefinite},
+	}
+}
+
+// closeAndDefinite appends missing closing bytes and re-encodes the result with definite lengths.
+func closeAndDefinite(glow []byte) ([]byte, error) {
+	closed, err := asn1.CloseIndefinite(glow)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.ToDefinite(closed)
+}
+
+// PopulateWithQuirks decodes glow into the collection, trying each profile in order until one succeeds, and returns
+// the name of that profile. Nil profiles tries DefaultQuirksProfiles. If no profile succeeds, the collection is left
+// unchanged and the errors of all profiles are returned.
+func (ec ElementCollection) PopulateWithQuirks(glow []byte, profiles []QuirksProfile) (string, error) {
+	if profiles == nil {
+		profiles = DefaultQuirksProfiles()
+	}
+
+	var errs []error
+
+	for _, p := range profiles {
+		data := glow
+
+		if p.Rewrite != nil {
+			var err error
+
+			data, err = p.Rewrite(glow)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("profile %s: %w", p.Name, err))
+
+				continue
+			}
+		}
+
+		decoded := NewElementCollection()
+
+		err := decoded.SafePopulate(asn1.NewDecoder(data))
+		if err == nil {
+			for k, el := range decoded {
+				ec[k] = el
+			}
+
+			return p.Name, nil
+		}
+
+		errs = append(errs, fmt.Errorf("profile %s: %w", p.Name, err))
+	}
+
+	return "", errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// indefiniteGlow is parseGlow with every constructed element re-encoded with indefinite length, which the standard
+// decoder does not handle at all nesting levels.
+//
+//nolint:gochecknoglobals
+var indefiniteGlow = []byte{
+	0x60, 0x80, 0x6B, 0x80, 0xA0, 0x80, 0x63, 0x80, 0xA0, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00, 0xA1,
+	0x80, 0x31, 0x80, 0xA0, 0x80, 0x0C, 0x14, 0x52, 0x33, 0x4C, 0x41, 0x59, 0x56, 0x69, 0x72, 0x74,
+	0x75, 0x61, 0x6C, 0x50, 0x61, 0x74, 0x63, 0x68, 0x42, 0x61, 0x79, 0x00, 0x00, 0xA1, 0x80, 0x0C,
+	0x00, 0x00, 0x00, 0xA4, 0x80, 0x0C, 0x00, 0x00, 0x00, 0xA3, 0x80, 0x01, 0x01, 0xFF, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+}
+
+func TestElementCollection_PopulateWithQuirks(t *testing.T) {
+	t.Parallel()
+
+	// vendorPrefix strips two bytes some imaginary device puts in front of every payload.
+	vendorPrefix := QuirksProfile{
+		Name:    "vendor-prefix",
+		Rewrite: func(glow []byte) ([]byte, error) { return glow[2:], nil },
+	}
+	prefixed := append([]byte{0xFF, 0xFF}, parseGlow...)
+
+	tests := []struct {
+		name        string
+		glow        []byte
+		profiles    []QuirksProfile
+		wantProfile string
+		wantErr     bool
+	}{
+		{"+standard", parseGlow, nil, "standard", false},
+		{"+definiteLength", indefiniteGlow, nil, "definite-length", false},
+		{"+missingTerminators", indefiniteGlow[:len(indefiniteGlow)-6], nil, "missing-terminators", false},
+		{"+customProfile", prefixed, append(DefaultQuirksProfiles(), vendorPrefix), "vendor-prefix", false},
+		{"-defaultProfiles", prefixed, nil, "", true},
+		{"-garbage", []byte{0x01, 0x02, 0x03}, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := ElementKey{ID: "existing", Path: "9"}
+			ec := ElementCollection{existing: &Element{Path: "9", Identifier: "existing"}}
+
+			got, err := ec.PopulateWithQuirks(tt.glow, tt.profiles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ElementCollection.PopulateWithQuirks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantProfile, got); diff != "" {
+				t.Fatalf("ElementCollection.PopulateWithQuirks() = %s", diff)
+			}
+
+			wantLen := 2
+			if tt.wantErr {
+				wantLen = 1
+			}
+
+			if _, ok := ec[existing]; !ok {
+				t.Fatalf("ElementCollection.PopulateWithQuirks() removed existing element")
+			}
+
+			if diff := cmp.Diff(wantLen, len(ec)); diff != "" {
+				t.Fatalf("ElementCollection.PopulateWithQuirks() elements = %s", diff)
+			}
+		})
+	}
+}
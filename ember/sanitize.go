@@ -0,0 +1,252 @@
+package ember
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+var (
+	// ErrInvalidUTF8 error when a decoded string is not valid UTF-8 and the UTF8Error policy is used.
+	ErrInvalidUTF8 = errors.New("invalid utf-8")
+	// ErrPathTooLong error when an element path has more components than allowed by DecodeOptions.
+	ErrPathTooLong = errors.New("path too long")
+)
+
+// DefaultTruncationMarker is appended to strings truncated by DecodeOptions.MaxStringLength.
+const DefaultTruncationMarker = "…"
+
+// UTF8Policy defines how decoded strings holding invalid UTF-8 are handled.
+type UTF8Policy int
+
+const (
+	// UTF8PassThrough keeps strings as decoded.
+	UTF8PassThrough UTF8Policy = iota
+	// UTF8Error fails decoding on the first invalid string.
+	UTF8Error
+	// UTF8Replace replaces invalid bytes with the Unicode replacement character.
+	UTF8Replace
+	// UTF8Raw replaces invalid bytes like UTF8Replace and keeps the original bytes in Element.RawStrings.
+	UTF8Raw
+)
+
+// DecodeOptions configures the checks PopulateWithOptions and Sanitize apply to decoded elements. The zero value
+// leaves elements unchanged.
+type DecodeOptions struct {
+	// UTF8 defines how strings with invalid UTF-8 are handled.
+	UTF8 UTF8Policy
+	// MaxStringLength caps identifiers and descriptions to this many bytes including the truncation marker, 0 means
+	// unlimited. Formats, enumerations and string values are never truncated, as that would change their meaning.
+	MaxStringLength int
+	// TruncationMarker is appended to truncated strings, DefaultTruncationMarker if empty.
+	TruncationMarker string
+	// MaxPathLength is the maximum number of path components of an element, 0 means unlimited.
+	MaxPathLength int
+	// Coercions converts parameter values registered per path or value type, before ValueHook is called.
+	Coercions *Coercions
+	// ValueHook is called for every parameter after the other options were applied, so vendor specific value
+	// semantics can be applied, e.g. mapping a sentinel value to no value by setting Value to nil and NullValue.
+	ValueHook ValueHook
+}
+
+// ValueHook overrides the interpretation of the value of the parameter at path, returning an error fails decoding.
+type ValueHook func(path string, el *Element) error
+
+// PopulateWithOptions works like Populate and then applies the options to all decoded elements. The elements are
+// added to the collection only if decoding and all checks succeed, on error the collection is left unchanged.
+func (ec ElementCollection) PopulateWithOptions(data *asn1.Decoder, opts DecodeOptions) error {
+	decoded := NewElementCollection()
+
+	err := decoded.Populate(data)
+	if err != nil {
+		return err
+	}
+
+	err = decoded.Sanitize(opts)
+	if err != nil {
+		return err
+	}
+
+	for k, el := range decoded {
+		ec[k] = el
+	}
+
+	return nil
+}
+
+// Sanitize applies the options to all elements of the collection, including children at any depth. Elements whose
+// identifier changed are stored under their new identifier. On error the elements checked so far stay sanitized.
+func (ec ElementCollection) Sanitize(opts DecodeOptions) error {
+	keys := make([]ElementKey, 0, len(ec))
+	for k := range ec {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return lessPath(keys[i].Path, keys[j].Path)
+	})
+
+	for _, k := range keys {
+		el := ec[k]
+		id := el.Identifier
+
+		err := walkElement(k.Path, el, func(p string, el *Element) error {
+			return opts.sanitize(p, el)
+		})
+
+		if el.Identifier != id && k.ID == id {
+			delete(ec, k)
+			ec[ElementKey{ID: el.Identifier, Path: k.Path}] = el
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// topLevel returns the elements of the collection keyed by path.
+func (ec ElementCollection) topLevel() map[string]*Element {
+	out := make(map[string]*Element, len(ec))
+	for k, el := range ec {
+		out[k.Path] = el
+	}
+
+	return out
+}
+
+// walkElement calls fn for el and all its children at any depth, with their full path.
+func walkElement(path string, el *Element, fn func(path string, el *Element) error) error {
+	err := fn(path, el)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range el.Children {
+		err = walkElement(path+"."+ch.Path, ch, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sanitize applies the options to a single element.
+func (opts DecodeOptions) sanitize(path string, el *Element) error {
+	if opts.MaxPathLength > 0 && strings.Count(path, ".")+1 > opts.MaxPathLength {
+		return fmt.Errorf("%w: element %.64s has more than %d components", ErrPathTooLong, path, opts.MaxPathLength)
+	}
+
+	strs := []struct {
+		name     string
+		value    *string
+		truncate bool
+	}{
+		{"identifier", &el.Identifier, true},
+		{"description", &el.Description, true},
+		{"format", &el.Format, false},
+		{"enumeration", &el.Enumeration, false},
+	}
+
+	for _, f := range strs {
+		out, err := opts.checkUTF8(path, f.name, *f.value, el)
+		if err != nil {
+			return err
+		}
+
+		if f.truncate {
+			out = opts.truncate(out)
+		}
+
+		*f.value = out
+	}
+
+	values := []struct {
+		name  string
+		value *any
+	}{
+		{"value", &el.Value},
+		{"default", &el.Default},
+	}
+
+	for _, f := range values {
+		str, ok := (*f.value).(string)
+		if !ok {
+			continue
+		}
+
+		out, err := opts.checkUTF8(path, f.name, str, el)
+		if err != nil {
+			return err
+		}
+
+		*f.value = out
+	}
+
+	if baseType(el.ElementType) != asn1.ParameterType {
+		return nil
+	}
+
+	err := opts.Coercions.apply(path, el)
+	if err != nil {
+		return err
+	}
+
+	if opts.ValueHook != nil {
+		return opts.ValueHook(path, el)
+	}
+
+	return nil
+}
+
+// checkUTF8 applies the UTF-8 policy to a string of the element.
+func (opts DecodeOptions) checkUTF8(path, field, s string, el *Element) (string, error) {
+	if opts.UTF8 == UTF8PassThrough || utf8.ValidString(s) {
+		return s, nil
+	}
+
+	switch opts.UTF8 {
+	case UTF8Error:
+		return "", fmt.Errorf("%w in %s of element %s", ErrInvalidUTF8, field, path)
+	case UTF8Raw:
+		if el.RawStrings == nil {
+			el.RawStrings = make(map[string][]byte)
+		}
+
+		el.RawStrings[field] = []byte(s)
+	}
+
+	return strings.ToValidUTF8(s, string(utf8.RuneError)), nil
+}
+
+// truncate caps s to MaxStringLength bytes, cutting at a rune boundary and appending the truncation marker. If the
+// limit leaves no room for the marker, s is cut without it.
+func (opts DecodeOptions) truncate(s string) string {
+	if opts.MaxStringLength <= 0 || len(s) <= opts.MaxStringLength {
+		return s
+	}
+
+	marker := opts.TruncationMarker
+	if marker == "" {
+		marker = DefaultTruncationMarker
+	}
+
+	n := opts.MaxStringLength - len(marker)
+	if n <= 0 {
+		marker = ""
+		n = opts.MaxStringLength
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n] + marker
+}
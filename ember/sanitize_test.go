@@ -0,0 +1,294 @@
+package ember
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// invalidUTF8Collection returns a node with a child parameter, both holding invalid UTF-8.
+func invalidUTF8Collection() ElementCollection {
+	return ElementCollection{
+		ElementKey{Path: "1", ID: "node"}: &Element{
+			Path:        "1",
+			ElementType: asn1.QualifiedNodeType,
+			Identifier:  "node",
+			Description: "Pegel \xfc",
+			Children: []*Element{
+				{Path: "2", ElementType: asn1.ParameterType, Identifier: "gain", Value: "a\xffb"},
+			},
+		},
+	}
+}
+
+func TestElementCollection_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    DecodeOptions
+		want    ElementCollection
+		wantErr bool
+	}{
+		{
+			"+passThrough",
+			DecodeOptions{},
+			invalidUTF8Collection(),
+			false,
+		},
+		{
+			"+replace",
+			DecodeOptions{UTF8: UTF8Replace},
+			ElementCollection{
+				ElementKey{Path: "1", ID: "node"}: &Element{
+					Path:        "1",
+					ElementType: asn1.QualifiedNodeType,
+					Identifier:  "node",
+					Description: "Pegel �",
+					Children: []*Element{
+						{Path: "2", ElementType: asn1.ParameterType, Identifier: "gain", Value: "a�b"},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"+raw",
+			DecodeOptions{UTF8: UTF8Raw},
+			ElementCollection{
+				ElementKey{Path: "1", ID: "node"}: &Element{
+					Path:        "1",
+					ElementType: asn1.QualifiedNodeType,
+					Identifier:  "node",
+					Description: "Pegel �",
+					RawStrings:  map[string][]byte{"description": []byte("Pegel \xfc")},
+					Children: []*Element{
+						{
+							Path:        "2",
+							ElementType: asn1.ParameterType,
+							Identifier:  "gain",
+							Value:       "a�b",
+							RawStrings:  map[string][]byte{"value": []byte("a\xffb")},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"-error",
+			DecodeOptions{UTF8: UTF8Error},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ec := invalidUTF8Collection()
+
+			err := ec.Sanitize(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ElementCollection.Sanitize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, ec); diff != "" {
+				t.Fatalf("ElementCollection.Sanitize() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestElementCollection_PopulateWithOptions(t *testing.T) {
+	t.Parallel()
+
+	glow := append([]byte(nil), parseGlow...)
+	glow[21] = 0xFF // first byte of the identifier
+
+	ec := NewElementCollection()
+
+	err := ec.PopulateWithOptions(asn1.NewDecoder(glow), DecodeOptions{UTF8: UTF8Error})
+	if err == nil {
+		t.Fatalf("ElementCollection.PopulateWithOptions() expected invalid utf-8 error")
+	}
+
+	if diff := cmp.Diff(0, len(ec)); diff != "" {
+		t.Fatalf("ElementCollection.PopulateWithOptions() elements = %s", diff)
+	}
+}
+
+func TestElementCollection_SanitizeRekeys(t *testing.T) {
+	t.Parallel()
+
+	ec := ElementCollection{
+		ElementKey{Path: "1", ID: "no\xffde"}: &Element{Path: "1", ElementType: asn1.QualifiedNodeType, Identifier: "no\xffde"},
+	}
+
+	err := ec.Sanitize(DecodeOptions{UTF8: UTF8Replace})
+	if err != nil {
+		t.Fatalf("ElementCollection.Sanitize() error = %v", err)
+	}
+
+	want := ElementCollection{
+		ElementKey{Path: "1", ID: "no\uFFFDde"}: &Element{Path: "1", ElementType: asn1.QualifiedNodeType, Identifier: "no\uFFFDde"},
+	}
+
+	if diff := cmp.Diff(want, ec); diff != "" {
+		t.Fatalf("ElementCollection.Sanitize() = %s", diff)
+	}
+}
+
+func TestDecodeOptions_truncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts DecodeOptions
+		in   string
+		want string
+	}{
+		{"+unlimited", DecodeOptions{}, "Main Fader", "Main Fader"},
+		{"+short", DecodeOptions{MaxStringLength: 10}, "Main Fader", "Main Fader"},
+		{"+truncated", DecodeOptions{MaxStringLength: 8}, "Main Fader", "Main …"},
+		{"+customMarker", DecodeOptions{MaxStringLength: 8, TruncationMarker: "..."}, "Main Fader", "Main ..."},
+		{"+runeBoundary", DecodeOptions{MaxStringLength: 5, TruncationMarker: "~"}, "Fadérs", "Fad~"},
+		{"+noRoomForMarker", DecodeOptions{MaxStringLength: 2, TruncationMarker: "..."}, "Main Fader", "Ma"},
+		{"+noRoomForDefaultMarker", DecodeOptions{MaxStringLength: 2}, "Fadérs", "Fa"},
+		{"+noRoomRuneBoundary", DecodeOptions{MaxStringLength: 1}, "éa", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.opts.truncate(tt.in)); diff != "" {
+				t.Fatalf("DecodeOptions.truncate() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestElementCollection_SanitizeLimits(t *testing.T) {
+	t.Parallel()
+
+	ec := invalidUTF8Collection()
+
+	err := ec.Sanitize(DecodeOptions{UTF8: UTF8Replace, MaxStringLength: 6, TruncationMarker: "~"})
+	if err != nil {
+		t.Fatalf("ElementCollection.Sanitize() error = %v", err)
+	}
+
+	if diff := cmp.Diff("Pegel~", ec[ElementKey{Path: "1", ID: "node"}].Description); diff != "" {
+		t.Fatalf("ElementCollection.Sanitize() = %s", diff)
+	}
+
+	formatted := ElementCollection{
+		ElementKey{Path: "1.3", ID: "level"}: &Element{
+			Path:        "1.3",
+			ElementType: asn1.QualifiedParameterType,
+			Identifier:  "level",
+			Format:      "%.2f dBFS",
+			Enumeration: "off\non",
+			Value:       "a long string value",
+		},
+	}
+
+	err = formatted.Sanitize(DecodeOptions{MaxStringLength: 5})
+	if err != nil {
+		t.Fatalf("ElementCollection.Sanitize() error = %v", err)
+	}
+
+	el := formatted[ElementKey{Path: "1.3", ID: "level"}]
+	if diff := cmp.Diff([]any{"%.2f dBFS", "off\non", "a long string value"}, []any{el.Format, el.Enumeration, el.Value}); diff != "" {
+		t.Fatalf("ElementCollection.Sanitize() truncated = %s", diff)
+	}
+
+	err = invalidUTF8Collection().Sanitize(DecodeOptions{MaxPathLength: 1})
+	if !errors.Is(err, ErrPathTooLong) {
+		t.Fatalf("ElementCollection.Sanitize() error = %v, want %v", err, ErrPathTooLong)
+	}
+}
+
+func TestElementCollection_PopulateNullValue(t *testing.T) {
+	t.Parallel()
+
+	// qualified integer parameter 1.2 "gain" with an explicit NULL value
+	glow := []byte{
+		0x60, 0x21, 0x6B, 0x1F, 0xA0, 0x1D, 0x69, 0x1B, 0xA0, 0x04, 0x0D, 0x02, 0x01, 0x02, 0xA1, 0x13,
+		0x31, 0x11, 0xA0, 0x06, 0x0C, 0x04, 0x67, 0x61, 0x69, 0x6E, 0xAD, 0x03, 0x02, 0x01, 0x01, 0xA2,
+		0x02, 0x05, 0x00,
+	}
+
+	tests := []struct {
+		name      string
+		hook      ValueHook
+		want      *Element
+		wantValue bool
+		wantErr   bool
+	}{
+		{
+			"+null",
+			nil,
+			&Element{Path: "1.2", ElementType: asn1.QualifiedParameterType, Identifier: "gain", ValueType: 1, NullValue: true},
+			false,
+			false,
+		},
+		{
+			"+hookOverride",
+			func(path string, el *Element) error {
+				if path == "1.2" && el.NullValue {
+					el.Value, el.NullValue = int64(-1), false
+				}
+
+				return nil
+			},
+			&Element{Path: "1.2", ElementType: asn1.QualifiedParameterType, Identifier: "gain", ValueType: 1, Value: int64(-1)},
+			true,
+			false,
+		},
+		{
+			"-hookError",
+			func(string, *Element) error { return ErrInvalidPath },
+			nil,
+			false,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ec := NewElementCollection()
+
+			err := ec.PopulateWithOptions(asn1.NewDecoder(append([]byte(nil), glow...)), DecodeOptions{ValueHook: tt.hook})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ElementCollection.PopulateWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := ec[ElementKey{Path: "1.2", ID: "gain"}]
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("ElementCollection.PopulateWithOptions() = %s", diff)
+			}
+
+			if got.HasValue() != tt.wantValue {
+				t.Fatalf("Element.HasValue() = %v, want %v", got.HasValue(), tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package ember
+
+import (
+	"sort"
+	"strings"
+)
+
+// SearchResult is an element found by a SearchIndex.
+type SearchResult struct {
+	Path        string      `json:"path"`
+	ElementType ElementType `json:"element_type"`
+	Identifier  string      `json:"identifier"`
+	Description string      `json:"description,omitempty"`
+	// Score rates fuzzy matches, lower is better. Substring matches always score 0.
+	Score int `json:"score"`
+}
+
+// SearchIndex is an in-memory index over identifiers and descriptions of a collection, e.g. to find all parameters
+// containing "phantom" in a facility. It is built once and not updated, rebuild it after the collection changed.
+type SearchIndex struct {
+	entries []searchEntry
+}
+
+// searchEntry is an indexed element with its lower case texts.
+type searchEntry struct {
+	result      SearchResult
+	identifier  string
+	description string
+}
+
+// NewSearchIndex indexes all elements of the collection, including children at any depth.
+func NewSearchIndex(ec ElementCollection) *SearchIndex {
+	var si SearchIndex
+
+	elements := ec.topLevel()
+
+	for path, el := range elements {
+		_ = walkElement(path, el, func(p string, el *Element) error {
+			si.entries = append(si.entries, searchEntry{
+				result: SearchResult{
+					Path:        p,
+					ElementType: el.ElementType,
+					Identifier:  el.Identifier,
+					Description: el.Description,
+				},
+				identifier:  strings.ToLower(el.Identifier),
+				description: strings.ToLower(el.Description),
+			})
+
+			return nil
+		})
+	}
+
+	sort.Slice(si.entries, func(i, j int) bool {
+		return lessPath(si.entries[i].result.Path, si.entries[j].result.Path)
+	})
+
+	return &si
+}
+
+// Len returns the number of indexed elements.
+func (si *SearchIndex) Len() int {
+	return len(si.entries)
+}
+
+// Search returns all elements whose identifier or description contains query, ignoring case, in path order.
+func (si *SearchIndex) Search(query string) []SearchResult {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil
+	}
+
+	var out []SearchResult
+
+	for _, e := range si.entries {
+		if strings.Contains(e.identifier, q) || strings.Contains(e.description, q) {
+			out = append(out, e.result)
+		}
+	}
+
+	return out
+}
+
+// SearchFuzzy returns all elements whose identifier or description contains the characters of query in order,
+// ignoring case, e.g. "phtm" finds "Phantom". Results are ordered by score, the number of characters skipped
+// between the first and last matched one, then by path.
+func (si *SearchIndex) SearchFuzzy(query string) []SearchResult {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil
+	}
+
+	var out []SearchResult
+
+	for _, e := range si.entries {
+		score, ok := fuzzyScore(e.identifier, q)
+
+		if descScore, descOK := fuzzyScore(e.description, q); descOK && (!ok || descScore < score) {
+			score, ok = descScore, true
+		}
+
+		if !ok {
+			continue
+		}
+
+		r := e.result
+		r.Score = score
+		out = append(out, r)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Score < out[j].Score
+	})
+
+	return out
+}
+
+// fuzzyScore matches the runes of query in order against text and returns the smallest number of runes skipped
+// between the first and last match, 0 for substrings.
+func fuzzyScore(text string, query string) (int, bool) {
+	t := []rune(text)
+	q := []rune(query)
+	best := -1
+
+	for start := range t {
+		if t[start] != q[0] {
+			continue
+		}
+
+		qi, gaps := 1, 0
+
+		for i := start + 1; i < len(t) && qi < len(q); i++ {
+			if t[i] == q[qi] {
+				qi++
+			} else {
+				gaps++
+			}
+		}
+
+		if qi == len(q) && (best < 0 || gaps < best) {
+			best = gaps
+		}
+	}
+
+	return best, best >= 0
+}
@@ -0,0 +1,91 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestSearchIndex(t *testing.T) {
+	t.Parallel()
+
+	ec := ElementCollection{
+		ElementKey{Path: "1.1"}: &Element{
+			Path:        "1.1",
+			ElementType: asn1.QualifiedNodeType,
+			Identifier:  "Mic 1",
+			Children: []*Element{
+				{Path: "10", ElementType: asn1.ParameterType, Identifier: "Phantom", Description: "48V supply"},
+				{Path: "2", ElementType: asn1.ParameterType, Identifier: "Gain"},
+			},
+		},
+		ElementKey{Path: "1.2.1", ID: "ph"}: &Element{
+			Path:        "1.2.1",
+			ElementType: asn1.QualifiedParameterType,
+			Identifier:  "ph",
+			Description: "PHANTOM power",
+		},
+		ElementKey{Path: "1.3", ID: "path"}: &Element{
+			Path:        "1.3",
+			ElementType: asn1.QualifiedParameterType,
+			Identifier:  "path",
+			Description: "patch bay",
+		},
+	}
+
+	si := NewSearchIndex(ec)
+
+	if si.Len() != 5 {
+		t.Fatalf("SearchIndex.Len() = %d, want 5", si.Len())
+	}
+
+	tests := []struct {
+		name   string
+		search func(string) []SearchResult
+		query  string
+		want   []SearchResult
+	}{
+		{
+			"+substring",
+			si.Search,
+			" phantom",
+			[]SearchResult{
+				{Path: "1.1.10", ElementType: asn1.ParameterType, Identifier: "Phantom", Description: "48V supply"},
+				{Path: "1.2.1", ElementType: asn1.QualifiedParameterType, Identifier: "ph", Description: "PHANTOM power"},
+			},
+		},
+		{
+			"+fuzzy",
+			si.SearchFuzzy,
+			"PHTM",
+			[]SearchResult{
+				{Path: "1.1.10", ElementType: asn1.ParameterType, Identifier: "Phantom", Description: "48V supply", Score: 3},
+				{Path: "1.2.1", ElementType: asn1.QualifiedParameterType, Identifier: "ph", Description: "PHANTOM power", Score: 3},
+			},
+		},
+		{
+			"+fuzzyRanking",
+			si.SearchFuzzy,
+			"pat",
+			[]SearchResult{
+				{Path: "1.3", ElementType: asn1.QualifiedParameterType, Identifier: "path", Description: "patch bay"},
+				{Path: "1.1.10", ElementType: asn1.ParameterType, Identifier: "Phantom", Description: "48V supply", Score: 2},
+				{Path: "1.2.1", ElementType: asn1.QualifiedParameterType, Identifier: "ph", Description: "PHANTOM power", Score: 2},
+			},
+		},
+		{"+noMatch", si.Search, "eq", nil},
+		{"+empty", si.SearchFuzzy, " ", nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.search(tt.query)); diff != "" {
+				t.Fatalf("SearchIndex search = %s", diff)
+			}
+		})
+	}
+}
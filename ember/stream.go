@@ -0,0 +1,154 @@
+package ember
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// ErrStreamFormat error when a stream value can not be read with the stream descriptor.
+var ErrStreamFormat = errors.New("invalid stream format")
+
+// StreamFormat defines how a parameter value is packed into an octet string stream entry.
+type StreamFormat int
+
+// stream formats as defined by the Glow DTD.
+const (
+	StreamUInt8     StreamFormat = 0
+	StreamUInt16BE  StreamFormat = 2
+	StreamUInt16LE  StreamFormat = 3
+	StreamUInt32BE  StreamFormat = 4
+	StreamUInt32LE  StreamFormat = 5
+	StreamUInt64BE  StreamFormat = 6
+	StreamUInt64LE  StreamFormat = 7
+	StreamInt8      StreamFormat = 8
+	StreamInt16BE   StreamFormat = 10
+	StreamInt16LE   StreamFormat = 11
+	StreamInt32BE   StreamFormat = 12
+	StreamInt32LE   StreamFormat = 13
+	StreamInt64BE   StreamFormat = 14
+	StreamInt64LE   StreamFormat = 15
+	StreamFloat32BE StreamFormat = 20
+	StreamFloat32LE StreamFormat = 21
+	StreamFloat64BE StreamFormat = 22
+	StreamFloat64LE StreamFormat = 23
+)
+
+// Size returns the number of bytes a value of the format takes, or 0 for unknown formats.
+func (f StreamFormat) Size() int {
+	switch f {
+	case StreamUInt8, StreamInt8:
+		return 1
+	case StreamUInt16BE, StreamUInt16LE, StreamInt16BE, StreamInt16LE:
+		return 2
+	case StreamUInt32BE, StreamUInt32LE, StreamInt32BE, StreamInt32LE, StreamFloat32BE, StreamFloat32LE:
+		return 4
+	case StreamUInt64BE, StreamUInt64LE, StreamInt64BE, StreamInt64LE, StreamFloat64BE, StreamFloat64LE:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// byteOrder returns the byte order of multi byte formats.
+func (f StreamFormat) byteOrder() binary.ByteOrder {
+	switch f {
+	case StreamUInt16LE, StreamUInt32LE, StreamUInt64LE, StreamInt16LE, StreamInt32LE, StreamInt64LE,
+		StreamFloat32LE, StreamFloat64LE:
+		return binary.LittleEndian
+	default:
+		return binary.BigEndian
+	}
+}
+
+// StreamDescriptor describes where the value of a streamed parameter is found in an octet string stream entry
+// shared by several parameters.
+type StreamDescriptor struct {
+	Format StreamFormat
+	Offset int
+}
+
+// Decode reads the value described by the descriptor from the octets of a stream entry.
+func (d StreamDescriptor) Decode(octets []byte) (float64, error) {
+	size := d.Format.Size()
+	if size == 0 {
+		return 0, fmt.Errorf("%w: unknown format %d", ErrStreamFormat, d.Format)
+	}
+
+	if d.Offset < 0 || d.Offset+size > len(octets) {
+		return 0, fmt.Errorf("%w: %d bytes at offset %d exceed %d octets", ErrStreamFormat, size, d.Offset, len(octets))
+	}
+
+	b := octets[d.Offset : d.Offset+size]
+	order := d.Format.byteOrder()
+
+	switch d.Format {
+	case StreamUInt8:
+		return float64(b[0]), nil
+	case StreamInt8:
+		return float64(int8(b[0])), nil
+	case StreamUInt16BE, StreamUInt16LE:
+		return float64(order.Uint16(b)), nil
+	case StreamInt16BE, StreamInt16LE:
+		return float64(int16(order.Uint16(b))), nil
+	case StreamUInt32BE, StreamUInt32LE:
+		return float64(order.Uint32(b)), nil
+	case StreamInt32BE, StreamInt32LE:
+		return float64(int32(order.Uint32(b))), nil
+	case StreamUInt64BE, StreamUInt64LE:
+		return float64(order.Uint64(b)), nil
+	case StreamInt64BE, StreamInt64LE:
+		return float64(int64(order.Uint64(b))), nil
+	case StreamFloat32BE, StreamFloat32LE:
+		return float64(math.Float32frombits(order.Uint32(b))), nil
+	default:
+		return math.Float64frombits(order.Uint64(b)), nil
+	}
+}
+
+// decodeStreamDescriptor decodes a Glow StreamDescription, an implicit sequence of definite or indefinite length,
+// returning the number of bytes used. Unknown fields are skipped.
+func decodeStreamDescriptor(in []byte, sd *StreamDescriptor) (int, error) {
+	tag, fields, rest, err := asn1.Next(in)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read stream description: %w", err)
+	}
+
+	if tag != asn1.ApplicationByte(uint8(asn1.TagStreamDescription)) {
+		return 0, fmt.Errorf("unexpected stream description tag 0x%02X", tag)
+	}
+
+	for len(fields) > 0 {
+		var (
+			fieldTag byte
+			field    []byte
+		)
+
+		fieldTag, field, fields, err = asn1.Next(fields)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read stream description field: %w", err)
+		}
+
+		switch fieldTag {
+		case asn1.ContextByte(asn1.ContextZeroTag):
+			format, err := asn1.NewDecoder(field).DecodeInteger()
+			if err != nil {
+				return 0, fmt.Errorf("failed to decode stream format: %w", err)
+			}
+
+			sd.Format = StreamFormat(format)
+		case asn1.ContextByte(asn1.ContextTagOne):
+			offset, err := asn1.NewDecoder(field).DecodeInteger()
+			if err != nil {
+				return 0, fmt.Errorf("failed to decode stream offset: %w", err)
+			}
+
+			sd.Offset = offset
+		}
+	}
+
+	return len(in) - len(rest), nil
+}
@@ -0,0 +1,268 @@
+package ember
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// ErrNotStreamCollection error when a glow message does not hold a stream collection.
+var ErrNotStreamCollection = errors.New("not a stream collection")
+
+const (
+	// realTag is the universal tag of an ASN.1 REAL value.
+	realTag = 0x09
+	// realBinary is set in the first content byte of binary encoded reals.
+	realBinary = 0x80
+	// realNegative is the sign bit of binary encoded reals.
+	realNegative = 0x40
+	// realBaseMask selects the base bits of binary encoded reals, only base 2 is used by Ember+.
+	realBaseMask = 0x30
+	// realScaleMask and realScaleShift select the scaling factor of binary encoded reals.
+	realScaleMask  = 0x0C
+	realScaleShift = 2
+	// realExponentMask selects the exponent length of binary encoded reals.
+	realExponentMask = 0x03
+)
+
+// StreamEntry is a single value of a stream collection, sent for all parameters subscribed with its identifier.
+type StreamEntry struct {
+	Identifier int
+	Value      any
+}
+
+// DecodeStreamCollection returns the entries of a glow stream collection message. Octet string values holding many
+// interleaved channels are returned as is, use a StreamDecoder to split them by parameter.
+func DecodeStreamCollection(glow []byte) ([]StreamEntry, error) {
+	tag, root, _, err := asn1.Next(glow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root: %w", err)
+	}
+
+	if tag != asn1.ApplicationByte(asn1.RootElementCollectionTag) {
+		return nil, fmt.Errorf("%w: root tag %x", ErrNotStreamCollection, tag)
+	}
+
+	tag, collection, _, err := asn1.Next(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stream collection: %w", err)
+	}
+
+	if tag != asn1.ApplicationByte(uint8(asn1.TagStreamCollection)) {
+		return nil, fmt.Errorf("%w: found %v", ErrNotStreamCollection, asn1.Tag(asn1.TagNumber(tag)))
+	}
+
+	var out []StreamEntry
+
+	for len(collection) > 0 {
+		var context []byte
+
+		_, context, collection, err = asn1.Next(collection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stream entry context: %w", err)
+		}
+
+		entry, err := decodeStreamEntry(context)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, entry)
+	}
+
+	return out, nil
+}
+
+// decodeStreamEntry decodes a single stream entry with its application tag.
+func decodeStreamEntry(data []byte) (StreamEntry, error) {
+	var entry StreamEntry
+
+	tag, fields, _, err := asn1.Next(data)
+	if err != nil {
+		return entry, fmt.Errorf("failed to read stream entry: %w", err)
+	}
+
+	if tag != asn1.ApplicationByte(uint8(asn1.TagStreamEntry)) {
+		return entry, fmt.Errorf("failed to read stream entry: unexpected %v", asn1.Tag(asn1.TagNumber(tag)))
+	}
+
+	for len(fields) > 0 {
+		var field []byte
+
+		tag, field, fields, err = asn1.Next(fields)
+		if err != nil {
+			return entry, fmt.Errorf("failed to read stream entry field: %w", err)
+		}
+
+		switch tag {
+		case asn1.ContextByte(0):
+			var id any
+
+			id, _, err = decodeUnknown(field)
+			if err != nil {
+				return entry, fmt.Errorf("failed to decode stream identifier: %w", err)
+			}
+
+			n, ok := id.(int64)
+			if !ok {
+				return entry, fmt.Errorf("failed to decode stream identifier: unexpected %T", id)
+			}
+
+			entry.Identifier = int(n)
+		case asn1.ContextByte(1):
+			entry.Value, err = decodeStreamValue(field)
+			if err != nil {
+				return entry, fmt.Errorf("failed to decode value of stream %d: %w", entry.Identifier, err)
+			}
+		}
+	}
+
+	return entry, nil
+}
+
+// decodeStreamValue decodes a glow value, which besides the types known to decodeUnknown may be a real.
+func decodeStreamValue(data []byte) (any, error) {
+	if len(data) > 0 && data[0] == realTag {
+		_, content, _, err := asn1.Next(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeReal(content)
+	}
+
+	v, _, err := decodeUnknown(data)
+
+	return v, err
+}
+
+// decodeReal decodes the content of a binary, base 2 encoded ASN.1 REAL as used by Ember+.
+func decodeReal(content []byte) (float64, error) {
+	if len(content) == 0 {
+		return 0, nil
+	}
+
+	first := content[0]
+
+	switch first {
+	case 0x40:
+		return math.Inf(1), nil
+	case 0x41:
+		return math.Inf(-1), nil
+	case 0x42:
+		return math.NaN(), nil
+	case 0x43:
+		return math.Copysign(0, -1), nil
+	}
+
+	if first&realBinary == 0 || first&realBaseMask != 0 {
+		return 0, fmt.Errorf("unsupported real encoding %x", first)
+	}
+
+	expLen := int(first&realExponentMask) + 1
+	pos := 1
+
+	if expLen == 4 {
+		if len(content) < 2 {
+			return 0, errors.New("truncated real exponent length")
+		}
+
+		expLen = int(content[1])
+		pos = 2
+	}
+
+	if expLen == 0 || len(content) < pos+expLen {
+		return 0, errors.New("truncated real exponent")
+	}
+
+	exponent := int(int8(content[pos]))
+	for _, b := range content[pos+1 : pos+expLen] {
+		exponent = exponent<<8 | int(b)
+	}
+
+	var mantissa uint64
+	for _, b := range content[pos+expLen:] {
+		mantissa = mantissa<<8 | uint64(b)
+	}
+
+	scale := int(first&realScaleMask) >> realScaleShift
+	out := math.Ldexp(float64(mantissa), exponent+scale)
+
+	if first&realNegative != 0 {
+		out = -out
+	}
+
+	return out, nil
+}
+
+// StreamDecoder splits stream entries into the values of the streamed parameters of a collection, de-interleaving
+// octet string entries shared by several parameters using their stream descriptors.
+type StreamDecoder struct {
+	channels map[int][]streamChannel
+}
+
+// streamChannel is a streamed parameter registered with a StreamDecoder.
+type streamChannel struct {
+	path       string
+	descriptor *StreamDescriptor
+}
+
+// NewStreamDecoder registers all streamed parameters of the collection, including children.
+func NewStreamDecoder(ec ElementCollection) *StreamDecoder {
+	sd := StreamDecoder{channels: make(map[int][]streamChannel)}
+	elements := ec.flatten()
+
+	for _, path := range sortedPaths(elements) {
+		el := elements[path]
+		if !isParameter(el) || !el.IsStreamed {
+			continue
+		}
+
+		sd.channels[el.StreamIdentifier] = append(sd.channels[el.StreamIdentifier], streamChannel{
+			path:       path,
+			descriptor: el.StreamDescriptor,
+		})
+	}
+
+	return &sd
+}
+
+// Decode returns the values of all registered parameters found in entries, keyed by parameter path. Values read
+// with a stream descriptor are returned as float64. Entries without registered parameters are ignored. Channels
+// that can not be decoded are reported in the returned error, all other values are still returned.
+func (sd *StreamDecoder) Decode(entries []StreamEntry) (map[string]any, error) {
+	out := make(map[string]any)
+
+	var errs []error
+
+	for _, entry := range entries {
+		for _, ch := range sd.channels[entry.Identifier] {
+			if ch.descriptor == nil {
+				out[ch.path] = entry.Value
+
+				continue
+			}
+
+			octets, ok := entry.Value.([]byte)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%w: stream %d of %s holds %T, not octets", ErrStreamFormat,
+					entry.Identifier, ch.path, entry.Value))
+
+				continue
+			}
+
+			v, err := ch.descriptor.Decode(octets)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to decode %s: %w", ch.path, err))
+
+				continue
+			}
+
+			out[ch.path] = v
+		}
+	}
+
+	return out, errors.Join(errs...)
+}
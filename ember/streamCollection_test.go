@@ -0,0 +1,150 @@
+package ember
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestDecodeStreamCollection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		glow    []byte
+		want    []StreamEntry
+		wantErr bool
+	}{
+		{
+			"+definite",
+			[]byte{
+				0x60, 0x20, 0x66, 0x1E,
+				0xA0, 0x0C, 0x65, 0x0A, 0xA0, 0x03, 0x02, 0x01, 0x01, 0xA1, 0x03, 0x02, 0x01, 0xEC,
+				0xA0, 0x0E, 0x65, 0x0C, 0xA0, 0x03, 0x02, 0x01, 0x02, 0xA1, 0x05, 0x09, 0x03, 0xC0, 0x01, 0x01,
+			},
+			[]StreamEntry{{Identifier: 1, Value: int64(-20)}, {Identifier: 2, Value: -2.0}},
+			false,
+		},
+		{
+			"+indefinite",
+			[]byte{
+				0x60, 0x80, 0x66, 0x80,
+				0xA0, 0x80, 0x65, 0x80, 0xA0, 0x03, 0x02, 0x01, 0x07, 0xA1, 0x80, 0x04, 0x02, 0x01, 0x02,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			[]StreamEntry{{Identifier: 7, Value: []byte{0x01, 0x02}}},
+			false,
+		},
+		{
+			"-elements",
+			[]byte{0x60, 0x02, 0x6B, 0x00},
+			nil,
+			true,
+		},
+		{
+			"-truncated",
+			[]byte{0x60, 0x06, 0x66, 0x04, 0xA0, 0x02, 0x65, 0x05},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DecodeStreamCollection(tt.glow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeStreamCollection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("DecodeStreamCollection() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeReal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    float64
+		wantErr bool
+	}{
+		{"+zero", nil, 0, false},
+		{"+one", []byte{0x80, 0x00, 0x01}, 1, false},
+		{"+fraction", []byte{0x80, 0xFF, 0x05}, 2.5, false},
+		{"+negative", []byte{0xC0, 0x02, 0x03}, -12, false},
+		{"+scaled", []byte{0x84, 0x00, 0x01}, 2, false},
+		{"+twoByteExponent", []byte{0x81, 0x00, 0x0A, 0x01}, 1024, false},
+		{"+minusInfinity", []byte{0x41}, math.Inf(-1), false},
+		{"-decimal", []byte{0x03, 0x31}, 0, true},
+		{"-truncated", []byte{0x81, 0x00}, 0, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodeReal(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeReal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("decodeReal() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamDecoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	ec := ElementCollection{
+		ElementKey{Path: "1.4", ID: "meters"}: &Element{
+			Path:        "1.4",
+			ElementType: asn1.QualifiedNodeType,
+			Children: []*Element{
+				{
+					Path: "1", ElementType: asn1.ParameterType, IsStreamed: true, StreamIdentifier: 10,
+					StreamDescriptor: &StreamDescriptor{Format: StreamInt16LE, Offset: 0},
+				},
+				{
+					Path: "2", ElementType: asn1.ParameterType, IsStreamed: true, StreamIdentifier: 10,
+					StreamDescriptor: &StreamDescriptor{Format: StreamInt16LE, Offset: 2},
+				},
+				{
+					Path: "3", ElementType: asn1.ParameterType, IsStreamed: true, StreamIdentifier: 10,
+					StreamDescriptor: &StreamDescriptor{Format: StreamInt16LE, Offset: 4},
+				},
+				{Path: "4", ElementType: asn1.ParameterType, IsStreamed: true, StreamIdentifier: 11},
+				{Path: "5", ElementType: asn1.ParameterType, Value: 1},
+			},
+		},
+	}
+
+	entries := []StreamEntry{
+		{Identifier: 10, Value: []byte{0xF6, 0xFF, 0x0A, 0x00}},
+		{Identifier: 11, Value: int64(-3)},
+		{Identifier: 12, Value: int64(9)},
+	}
+
+	got, err := NewStreamDecoder(ec).Decode(entries)
+	if err == nil {
+		t.Fatalf("StreamDecoder.Decode() expected error for the short entry of 1.4.3")
+	}
+
+	want := map[string]any{"1.4.1": -10.0, "1.4.2": 10.0, "1.4.4": int64(-3)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("StreamDecoder.Decode() = %s", diff)
+	}
+}
@@ -0,0 +1,47 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStreamDescriptor_Decode(t *testing.T) {
+	t.Parallel()
+
+	octets := []byte{0xFF, 0x00, 0x10, 0x00, 0x00, 0x80, 0x3F, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xF0, 0xBF}
+
+	tests := []struct {
+		name    string
+		sd      StreamDescriptor
+		want    float64
+		wantErr bool
+	}{
+		{"+uint8", StreamDescriptor{Format: StreamUInt8, Offset: 0}, 255, false},
+		{"+int8", StreamDescriptor{Format: StreamInt8, Offset: 0}, -1, false},
+		{"+uint16BE", StreamDescriptor{Format: StreamUInt16BE, Offset: 1}, 16, false},
+		{"+uint16LE", StreamDescriptor{Format: StreamUInt16LE, Offset: 1}, 4096, false},
+		{"+int16LE", StreamDescriptor{Format: StreamInt16LE, Offset: 0}, 255, false},
+		{"+float32LE", StreamDescriptor{Format: StreamFloat32LE, Offset: 3}, 1, false},
+		{"+float64LE", StreamDescriptor{Format: StreamFloat64LE, Offset: 7}, -1, false},
+		{"-unknownFormat", StreamDescriptor{Format: 1, Offset: 0}, 0, true},
+		{"-beyondOctets", StreamDescriptor{Format: StreamInt32BE, Offset: 12}, 0, true},
+		{"-negativeOffset", StreamDescriptor{Format: StreamUInt8, Offset: -1}, 0, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.sd.Decode(octets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StreamDescriptor.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("StreamDescriptor.Decode() = %s", diff)
+			}
+		})
+	}
+}
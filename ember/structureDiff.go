@@ -0,0 +1,124 @@
+package ember
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// ChangeKind describes how the structure of a tree changed between two collections.
+type ChangeKind string
+
+const (
+	// ElementAdded element exists only in the newer collection.
+	ElementAdded ChangeKind = "added"
+	// ElementRemoved element exists only in the older collection.
+	ElementRemoved ChangeKind = "removed"
+	// ElementRenamed element exists in both collections, but its identifier changed.
+	ElementRenamed ChangeKind = "renamed"
+	// ElementRetyped element exists in both collections, but its element type changed.
+	ElementRetyped ChangeKind = "retyped"
+)
+
+// StructureChange is a single structural difference between two collections of the same device, as detected by
+// DiffStructure.
+type StructureChange struct {
+	Kind          ChangeKind  `json:"kind"`
+	Path          string      `json:"path"`
+	OldIdentifier string      `json:"old_identifier,omitempty"`
+	NewIdentifier string      `json:"new_identifier,omitempty"`
+	OldType       ElementType `json:"old_type,omitempty"`
+	NewType       ElementType `json:"new_type,omitempty"`
+}
+
+// String returns a short human readable description of the change.
+func (sc StructureChange) String() string {
+	switch sc.Kind {
+	case ElementAdded:
+		return fmt.Sprintf("%s %s %q added", sc.NewType, sc.Path, sc.NewIdentifier)
+	case ElementRemoved:
+		return fmt.Sprintf("%s %s %q removed", sc.OldType, sc.Path, sc.OldIdentifier)
+	case ElementRenamed:
+		return fmt.Sprintf("%s %s renamed from %q to %q", sc.NewType, sc.Path, sc.OldIdentifier, sc.NewIdentifier)
+	default:
+		return fmt.Sprintf("%s changed type from %s to %s", sc.Path, sc.OldType, sc.NewType)
+	}
+}
+
+// DiffStructure compares two collections read from the same device, e.g. before and after a firmware update, and
+// returns elements that appeared, disappeared, were renamed or changed their type, ordered by path. Value changes
+// are not reported. Qualified and non qualified variants of the same type are considered equal.
+func DiffStructure(older ElementCollection, newer ElementCollection) []StructureChange {
+	oldElements := older.flatten()
+	newElements := newer.flatten()
+
+	var changes []StructureChange
+
+	for _, path := range sortedPaths(oldElements) {
+		if _, ok := newElements[path]; ok {
+			continue
+		}
+
+		el := oldElements[path]
+		changes = append(changes, StructureChange{
+			Kind:          ElementRemoved,
+			Path:          path,
+			OldIdentifier: el.Identifier,
+			OldType:       el.ElementType,
+		})
+	}
+
+	for _, path := range sortedPaths(newElements) {
+		el := newElements[path]
+
+		prev, ok := oldElements[path]
+		if !ok {
+			changes = append(changes, StructureChange{
+				Kind:          ElementAdded,
+				Path:          path,
+				NewIdentifier: el.Identifier,
+				NewType:       el.ElementType,
+			})
+
+			continue
+		}
+
+		change := StructureChange{
+			Path:          path,
+			OldIdentifier: prev.Identifier,
+			NewIdentifier: el.Identifier,
+			OldType:       prev.ElementType,
+			NewType:       el.ElementType,
+		}
+
+		switch {
+		case baseType(prev.ElementType) != baseType(el.ElementType):
+			change.Kind = ElementRetyped
+		case prev.Identifier != el.Identifier:
+			change.Kind = ElementRenamed
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return lessPath(changes[i].Path, changes[j].Path)
+	})
+
+	return changes
+}
+
+// baseType maps qualified element types to their non qualified counterpart.
+func baseType(et ElementType) ElementType {
+	switch et {
+	case asn1.QualifiedNodeType:
+		return asn1.NodeType
+	case asn1.QualifiedParameterType:
+		return asn1.ParameterType
+	default:
+		return et
+	}
+}
@@ -0,0 +1,120 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestDiffStructure(t *testing.T) {
+	t.Parallel()
+
+	older := ElementCollection{
+		ElementKey{Path: "1.1"}: &Element{
+			Path:        "1.1",
+			ElementType: asn1.QualifiedNodeType,
+			Children: []*Element{
+				{Path: "1", ElementType: asn1.ParameterType, Identifier: "On", Value: true},
+				{Path: "2", ElementType: asn1.ParameterType, Identifier: "Gain", Value: 0},
+				{Path: "3", ElementType: asn1.NodeType, Identifier: "Compressor"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		newer ElementCollection
+		want  []StructureChange
+	}{
+		{
+			"+unchangedValuesIgnored",
+			ElementCollection{
+				ElementKey{Path: "1.1"}: &Element{
+					Path:        "1.1",
+					ElementType: asn1.QualifiedNodeType,
+					Children: []*Element{
+						{Path: "1", ElementType: asn1.ParameterType, Identifier: "On", Value: false},
+						{Path: "2", ElementType: asn1.QualifiedParameterType, Identifier: "Gain", Value: -6},
+						{Path: "3", ElementType: asn1.NodeType, Identifier: "Compressor"},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"+changes",
+			ElementCollection{
+				ElementKey{Path: "1.1"}: &Element{
+					Path:        "1.1",
+					ElementType: asn1.QualifiedNodeType,
+					Children: []*Element{
+						{Path: "2", ElementType: asn1.ParameterType, Identifier: "Corr Gain[dB]"},
+						{Path: "3", ElementType: asn1.FunctionType, Identifier: "Compressor"},
+						{Path: "4", ElementType: asn1.NodeType, Identifier: "Expander"},
+						{Path: "10", ElementType: asn1.NodeType, Identifier: "Limiter"},
+					},
+				},
+			},
+			[]StructureChange{
+				{Kind: ElementRemoved, Path: "1.1.1", OldIdentifier: "On", OldType: asn1.ParameterType},
+				{
+					Kind:          ElementRenamed,
+					Path:          "1.1.2",
+					OldIdentifier: "Gain",
+					NewIdentifier: "Corr Gain[dB]",
+					OldType:       asn1.ParameterType,
+					NewType:       asn1.ParameterType,
+				},
+				{
+					Kind:          ElementRetyped,
+					Path:          "1.1.3",
+					OldIdentifier: "Compressor",
+					NewIdentifier: "Compressor",
+					OldType:       asn1.NodeType,
+					NewType:       asn1.FunctionType,
+				},
+				{Kind: ElementAdded, Path: "1.1.4", NewIdentifier: "Expander", NewType: asn1.NodeType},
+				{Kind: ElementAdded, Path: "1.1.10", NewIdentifier: "Limiter", NewType: asn1.NodeType},
+			},
+		},
+		{
+			"+deviceGone",
+			ElementCollection{},
+			[]StructureChange{
+				{Kind: ElementRemoved, Path: "1.1", OldType: asn1.QualifiedNodeType},
+				{Kind: ElementRemoved, Path: "1.1.1", OldIdentifier: "On", OldType: asn1.ParameterType},
+				{Kind: ElementRemoved, Path: "1.1.2", OldIdentifier: "Gain", OldType: asn1.ParameterType},
+				{Kind: ElementRemoved, Path: "1.1.3", OldIdentifier: "Compressor", OldType: asn1.NodeType},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := DiffStructure(older, tt.newer)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("DiffStructure() = %s", diff)
+			}
+		})
+	}
+}
+
+func TestStructureChange_String(t *testing.T) {
+	t.Parallel()
+
+	sc := StructureChange{
+		Kind:          ElementRenamed,
+		Path:          "1.2",
+		OldIdentifier: "Gain",
+		NewIdentifier: "Trim",
+		NewType:       asn1.ParameterType,
+	}
+
+	if diff := cmp.Diff(`parameter 1.2 renamed from "Gain" to "Trim"`, sc.String()); diff != "" {
+		t.Fatalf("StructureChange.String() = %s", diff)
+	}
+}
@@ -0,0 +1,98 @@
+package ember
+
+import (
+	"sync"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// SyncCollection is an element collection safe for concurrent use, e.g. by a read loop populating updates while a
+// crawl adds further sub trees. Data is decoded outside the lock and merged afterwards, so slow decoding never blocks
+// readers. Elements returned by it are shared and must not be modified.
+type SyncCollection struct {
+	mu sync.RWMutex
+	ec ElementCollection
+}
+
+// NewSyncCollection creates an empty synchronized collection.
+func NewSyncCollection() *SyncCollection {
+	return &SyncCollection{ec: NewElementCollection()}
+}
+
+// Populate works like ElementCollection.Populate. Elements decoded before an error are merged as well.
+func (sc *SyncCollection) Populate(data *asn1.Decoder) error {
+	decoded := NewElementCollection()
+	err := decoded.SafePopulate(data)
+
+	sc.Merge(decoded)
+
+	return err
+}
+
+// PopulateWithOptions works like ElementCollection.PopulateWithOptions, nothing is merged if the options reject the
+// decoded elements.
+func (sc *SyncCollection) PopulateWithOptions(data *asn1.Decoder, opts DecodeOptions) error {
+	decoded := NewElementCollection()
+
+	err := decoded.SafePopulate(data)
+	if err != nil {
+		sc.Merge(decoded)
+
+		return err
+	}
+
+	err = decoded.Sanitize(opts)
+	if err != nil {
+		return err
+	}
+
+	sc.Merge(decoded)
+
+	return nil
+}
+
+// Merge adds all elements of other, replacing elements with the same key.
+func (sc *SyncCollection) Merge(other ElementCollection) {
+	sc.Update(func(ec ElementCollection) {
+		for key, el := range other {
+			ec[key] = el
+		}
+	})
+}
+
+// Update calls fn with the collection while holding the write lock, fn must not keep a reference to it.
+func (sc *SyncCollection) Update(fn func(ec ElementCollection)) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	fn(sc.ec)
+}
+
+// GetElementByPath works like ElementCollection.GetElementByPath.
+func (sc *SyncCollection) GetElementByPath(path string) (*Element, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	return sc.ec.GetElementByPath(path)
+}
+
+// Len returns the number of top level elements.
+func (sc *SyncCollection) Len() int {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	return len(sc.ec)
+}
+
+// Snapshot returns a copy of the collection, which can be used without locking, e.g. for JSON exports.
+func (sc *SyncCollection) Snapshot() ElementCollection {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	out := make(ElementCollection, len(sc.ec))
+	for key, el := range sc.ec {
+		out[key] = el
+	}
+
+	return out
+}
@@ -0,0 +1,73 @@
+package ember
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestSyncCollection_ConcurrentPopulate(t *testing.T) {
+	t.Parallel()
+
+	sc := NewSyncCollection()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if err := sc.Populate(asn1.NewDecoder(append([]byte{}, parseGlow...))); err != nil {
+				t.Errorf("SyncCollection.Populate() error = %v", err)
+			}
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+
+			path := fmt.Sprintf("2.%d", i)
+			sc.Merge(ElementCollection{ElementKey{Path: path}: &Element{Path: path, ElementType: asn1.QualifiedNodeType}})
+			_ = sc.Snapshot()
+		}(i)
+	}
+
+	wg.Wait()
+
+	if got := sc.Len(); got != 9 {
+		t.Fatalf("SyncCollection.Len() = %d, want 9", got)
+	}
+
+	el, err := sc.GetElementByPath("1")
+	if err != nil {
+		t.Fatalf("SyncCollection.GetElementByPath() error = %v", err)
+	}
+
+	if diff := cmp.Diff("R3LAYVirtualPatchBay", el.Identifier); diff != "" {
+		t.Fatalf("SyncCollection.GetElementByPath() = %s", diff)
+	}
+}
+
+func TestSyncCollection_PopulateWithOptions(t *testing.T) {
+	t.Parallel()
+
+	sc := NewSyncCollection()
+
+	err := sc.PopulateWithOptions(asn1.NewDecoder(append([]byte{}, parseGlow...)), DecodeOptions{MaxPathLength: 1})
+	if err != nil {
+		t.Fatalf("SyncCollection.PopulateWithOptions() error = %v", err)
+	}
+
+	err = sc.PopulateWithOptions(asn1.NewDecoder([]byte{0x60, 0x00}), DecodeOptions{})
+	if err == nil {
+		t.Fatalf("SyncCollection.PopulateWithOptions() error = nil, want error")
+	}
+
+	if got := sc.Len(); got != 1 {
+		t.Fatalf("SyncCollection.Len() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,54 @@
+package ember
+
+import (
+	"reflect"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+// accessWrite is the bit set in the access of parameters, which can be written by consumers.
+const accessWrite = 2
+
+// ValueChange is a single parameter value, which differs between two collections, as detected by DiffValues.
+type ValueChange struct {
+	Path       string `json:"path"`
+	Identifier string `json:"identifier,omitempty"`
+	Old        any    `json:"old"`
+	New        any    `json:"new"`
+}
+
+// IsWritable returns true if the element is a parameter, which can be written by consumers.
+func (el *Element) IsWritable() bool {
+	return baseType(el.ElementType) == asn1.ParameterType && el.Access&accessWrite != 0
+}
+
+// DiffValues compares the parameters of two collections read from devices of the same model and returns the
+// values, which need to be written to target to match source, ordered by path. Only parameters, which exist in both
+// collections and are writable in target, are reported.
+func DiffValues(source ElementCollection, target ElementCollection) []ValueChange {
+	sourceElements := source.flatten()
+	targetElements := target.flatten()
+
+	var changes []ValueChange
+
+	for _, path := range sortedPaths(sourceElements) {
+		el := sourceElements[path]
+		if baseType(el.ElementType) != asn1.ParameterType || el.Value == nil {
+			continue
+		}
+
+		dst, ok := targetElements[path]
+		if !ok || !dst.IsWritable() || reflect.DeepEqual(el.Value, dst.Value) {
+			continue
+		}
+
+		changes = append(changes, ValueChange{
+			Path:       path,
+			Identifier: dst.Identifier,
+			Old:        dst.Value,
+			New:        el.Value,
+		})
+	}
+
+	return changes
+}
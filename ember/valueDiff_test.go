@@ -0,0 +1,85 @@
+package ember
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+)
+
+func TestDiffValues(t *testing.T) {
+	t.Parallel()
+
+	source := ElementCollection{
+		ElementKey{Path: "1.1"}: &Element{
+			Path:        "1.1",
+			ElementType: asn1.QualifiedNodeType,
+			Children: []*Element{
+				{Path: "1", ElementType: asn1.ParameterType, Identifier: "On", Value: true, Access: 3},
+				{Path: "2", ElementType: asn1.ParameterType, Identifier: "Gain", Value: int64(-6), Access: 3},
+				{Path: "3", ElementType: asn1.ParameterType, Identifier: "Level", Value: int64(-40), Access: 1},
+				{Path: "4", ElementType: asn1.NodeType, Identifier: "Compressor"},
+			},
+		},
+		ElementKey{Path: "1.2", ID: "name"}: &Element{
+			Path:        "1.2",
+			ElementType: asn1.QualifiedParameterType,
+			Identifier:  "name",
+			Value:       "Studio A",
+			Access:      3,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target ElementCollection
+		want   []ValueChange
+	}{
+		{
+			"+equal",
+			source,
+			nil,
+		},
+		{
+			"+differing",
+			ElementCollection{
+				ElementKey{Path: "1.1"}: &Element{
+					Path:        "1.1",
+					ElementType: asn1.QualifiedNodeType,
+					Children: []*Element{
+						{Path: "1", ElementType: asn1.ParameterType, Identifier: "On", Value: false, Access: 2},
+						{Path: "2", ElementType: asn1.ParameterType, Identifier: "Gain", Value: int64(0), Access: 1},
+						{Path: "3", ElementType: asn1.ParameterType, Identifier: "Level", Value: int64(-3), Access: 1},
+					},
+				},
+				ElementKey{Path: "1.2", ID: "name"}: &Element{
+					Path:        "1.2",
+					ElementType: asn1.QualifiedParameterType,
+					Identifier:  "name",
+					Value:       "Spare",
+					Access:      3,
+				},
+			},
+			[]ValueChange{
+				{Path: "1.1.1", Identifier: "On", Old: false, New: true},
+				{Path: "1.2", Identifier: "name", Old: "Spare", New: "Studio A"},
+			},
+		},
+		{
+			"+missingTarget",
+			ElementCollection{},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, DiffValues(source, tt.target)); diff != "" {
+				t.Fatalf("DiffValues() = %s", diff)
+			}
+		})
+	}
+}
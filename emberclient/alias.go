@@ -0,0 +1,117 @@
+package emberclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+var (
+	// ErrUnknownAlias error when a name is neither a known alias nor a valid path.
+	ErrUnknownAlias = errors.New("unknown alias")
+	// ErrAliasOtherDevice error when an alias points to a different device than the client is connected to.
+	ErrAliasOtherDevice = errors.New("alias refers to another device")
+)
+
+// Alias is the target of a stable name, the device given as "host:port" and the element path on that device.
+type Alias struct {
+	Device string `json:"device"`
+	Path   string `json:"path"`
+}
+
+// AliasTable maps stable names such as "StudioA.MainFader" to device paths, so automation survives repatching that
+// changes OIDs by updating the table instead of every script.
+type AliasTable map[string]Alias
+
+// LoadAliases reads an alias table from JSON in the form {"StudioA.MainFader": {"device": "10.0.0.5:9000", "path":
+// "1.4.2.7"}}. Devices and paths are validated while loading, paths are stored in dotted form, e.g. "1/4/2/7" becomes
+// "1.4.2.7".
+func LoadAliases(r io.Reader) (AliasTable, error) {
+	var table AliasTable
+
+	err := json.NewDecoder(r).Decode(&table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode alias table: %w", err)
+	}
+
+	for name, alias := range table {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.New("alias table contains an empty alias name")
+		}
+
+		_, _, err = net.SplitHostPort(alias.Device)
+		if err != nil {
+			return nil, fmt.Errorf("alias %q has invalid device %q: %w", name, alias.Device, err)
+		}
+
+		_, err = ember.ParsePath(alias.Path)
+		if err != nil {
+			return nil, fmt.Errorf("alias %q: %w", name, err)
+		}
+
+		alias.Path = normalizePath(alias.Path)
+		table[name] = alias
+	}
+
+	return table, nil
+}
+
+// Resolve returns the alias with the given name.
+func (t AliasTable) Resolve(name string) (Alias, error) {
+	alias, ok := t[name]
+	if !ok {
+		return Alias{}, fmt.Errorf("%w: %q", ErrUnknownAlias, name)
+	}
+
+	return alias, nil
+}
+
+// ResolveFor returns the path for name on the given device. Names that are not in the table but are valid paths are
+// returned unchanged, so callers can accept both aliases and raw paths.
+func (t AliasTable) ResolveFor(device string, name string) (string, error) {
+	alias, err := t.Resolve(name)
+	if err != nil {
+		if _, perr := ember.ParsePath(name); perr == nil {
+			return name, nil
+		}
+
+		return "", err
+	}
+
+	if !sameDevice(alias.Device, device) {
+		return "", fmt.Errorf("%w: %q is on %s, not %s", ErrAliasOtherDevice, name, alias.Device, device)
+	}
+
+	return alias.Path, nil
+}
+
+// GetByAlias works like GetByType, but accepts an alias from the table or a raw path.
+func (ec *EmberClient) GetByAlias(t AliasTable, emberType ember.ElementType, name string) ([]byte, error) {
+	path, err := t.ResolveFor(ec.raddr, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.GetByType(emberType, path)
+}
+
+// sameDevice compares two "host:port" device addresses, ignoring the case of host names.
+func sameDevice(a string, b string) bool {
+	hostA, portA, errA := net.SplitHostPort(a)
+	hostB, portB, errB := net.SplitHostPort(b)
+
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	pa, _ := strconv.Atoi(portA)
+	pb, _ := strconv.Atoi(portB)
+
+	return strings.EqualFold(hostA, hostB) && pa == pb
+}
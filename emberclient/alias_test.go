@@ -0,0 +1,68 @@
+package emberclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const aliasJSON = `{
+	"StudioA.MainFader": {"device": "10.0.0.5:9000", "path": "1.4.2.7"},
+	"StudioB.MainFader": {"device": "mixer-b:9000", "path": "1/4/2/7"}
+}`
+
+func TestLoadAliasesValidReturnsTable(t *testing.T) {
+	table, err := LoadAliases(strings.NewReader(aliasJSON))
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, len(table))
+	assert.EqualValues(t, Alias{Device: "10.0.0.5:9000", Path: "1.4.2.7"}, table["StudioA.MainFader"])
+}
+
+func TestLoadAliasesInvalidJSONReturnsError(t *testing.T) {
+	table, err := LoadAliases(strings.NewReader(`{"a": `))
+	assert.Nil(t, table)
+	assert.NotNil(t, err)
+}
+
+func TestLoadAliasesInvalidDeviceReturnsError(t *testing.T) {
+	table, err := LoadAliases(strings.NewReader(`{"a": {"device": "10.0.0.5", "path": "1"}}`))
+	assert.Nil(t, table)
+	assert.NotNil(t, err)
+}
+
+func TestLoadAliasesInvalidPathReturnsError(t *testing.T) {
+	table, err := LoadAliases(strings.NewReader(`{"a": {"device": "10.0.0.5:9000", "path": "1.x"}}`))
+	assert.Nil(t, table)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `component 2 "x" is not a number`)
+}
+
+func TestResolveForAliasReturnsPath(t *testing.T) {
+	table, _ := LoadAliases(strings.NewReader(aliasJSON))
+	path, err := table.ResolveFor("MIXER-B:9000", "StudioB.MainFader")
+	assert.Nil(t, err)
+	assert.EqualValues(t, "1.4.2.7", path)
+}
+
+func TestResolveForRawPathReturnsPath(t *testing.T) {
+	table, _ := LoadAliases(strings.NewReader(aliasJSON))
+	path, err := table.ResolveFor("10.0.0.5:9000", "1.2.3")
+	assert.Nil(t, err)
+	assert.EqualValues(t, "1.2.3", path)
+}
+
+func TestResolveForOtherDeviceReturnsError(t *testing.T) {
+	table, _ := LoadAliases(strings.NewReader(aliasJSON))
+	path, err := table.ResolveFor("10.0.0.6:9000", "StudioA.MainFader")
+	assert.EqualValues(t, "", path)
+	assert.True(t, errors.Is(err, ErrAliasOtherDevice))
+}
+
+func TestResolveForUnknownReturnsError(t *testing.T) {
+	table, _ := LoadAliases(strings.NewReader(aliasJSON))
+	path, err := table.ResolveFor("10.0.0.5:9000", "StudioC.MainFader")
+	assert.EqualValues(t, "", path)
+	assert.True(t, errors.Is(err, ErrUnknownAlias))
+}
@@ -5,16 +5,26 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/johannes-kuhfuss/emberplus/asn1"
 	"github.com/johannes-kuhfuss/emberplus/ember"
 	"github.com/johannes-kuhfuss/emberplus/s101"
-	"github.com/johannes-kuhfuss/services_utils/logger"
 )
 
+// dialTimeout limits how long Connect waits for the TCP connection to be established.
+const dialTimeout = 10 * time.Second
+
 type EmberClient struct {
-	raddr string
-	conn  net.Conn
+	raddr         string
+	conn          net.Conn
+	filterStreams bool
+	streams       chan<- []byte
+	dnsCache      *DNSCache
+	lastDial      DialMetrics
+	clock         Clock
+	pendingWrites *PendingWrites
+	logger        Logger
 }
 
 func NewEmberClient(host string, port int) (*EmberClient, error) {
@@ -30,6 +40,15 @@ func NewEmberClient(host string, port int) (*EmberClient, error) {
 	return &ec, nil
 }
 
+// NewEmberClientWithConn creates a client on top of an already established connection, e.g. one end of a
+// net.Pipe served by a mock provider in tests.
+func NewEmberClientWithConn(conn net.Conn) *EmberClient {
+	return &EmberClient{
+		raddr: conn.RemoteAddr().String(),
+		conn:  conn,
+	}
+}
+
 func (ec *EmberClient) IsConnected() bool {
 	return ec.conn != nil
 }
@@ -37,16 +56,16 @@ func (ec *EmberClient) IsConnected() bool {
 func (ec *EmberClient) Connect() error {
 	if ec.IsConnected() {
 		err := errors.New("already connected")
-		logger.Errorf("Cannot connect Ember to %v, %v", ec.raddr, err)
+		ec.log().Errorf("Cannot connect Ember to %v, %v", ec.raddr, err)
 		return err
 	}
-	conn, err := net.Dial("tcp", ec.raddr)
+	conn, err := ec.dial()
 	if err != nil {
-		logger.Errorf("Cannot not connect Ember to %v, %v", ec.raddr, err)
-		return err
+		ec.log().Errorf("Cannot not connect Ember to %v, %v", ec.raddr, err)
+		return classifyDialError(err)
 	}
 	ec.conn = conn
-	logger.Infof("Connected to Ember producer %v.", ec.raddr)
+	ec.log().Infof("Connected to Ember producer %v.", ec.raddr)
 	return nil
 }
 
@@ -57,10 +76,10 @@ func (ec *EmberClient) Disconnect() error {
 	err := ec.conn.Close()
 	ec.conn = nil
 	if err != nil {
-		logger.Errorf("Error while disconnecting Ember from %v: %v", ec.raddr, err)
+		ec.log().Errorf("Error while disconnecting Ember from %v: %v", ec.raddr, err)
 		return err
 	}
-	logger.Infof("Disconnected Ember from %v.", ec.raddr)
+	ec.log().Infof("Disconnected Ember from %v.", ec.raddr)
 	return nil
 }
 
@@ -99,16 +118,21 @@ func (ec *EmberClient) Receive() ([]byte, error) {
 
 			s101s, incompleteS101, err = s101.GetS101s(response)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get s101 data from read: %w", err)
+				return nil, fmt.Errorf("%w: failed to get s101 data from read: %w", ErrProtocol, err)
 			}
 
 			if len(incompleteS101) > 0 {
 				continue
 			}
 
+			s101s = ec.answerKeepAlives(s101s)
+			if len(s101s) == 0 {
+				continue
+			}
+
 			glow, lastPacketType, err := s101.Decode(s101s)
 			if err != nil {
-				logger.Debugf("failed to decode response: %s", err.Error())
+				ec.log().Debugf("failed to decode response: %s", err.Error())
 				continue
 			}
 			switch lastPacketType {
@@ -118,14 +142,21 @@ func (ec *EmberClient) Receive() ([]byte, error) {
 				continue
 			case s101.LastMultiPacket:
 				out = append(out, glow...)
+				if ec.skipStreams(out) {
+					out, multi = nil, false
+					continue
+				}
 				return out, nil
 			default:
 				if multi {
-					err = errors.New("dropping message in the middle of a multi packet read")
-					logger.Error("package processing error", err)
+					err = fmt.Errorf("%w: dropping message in the middle of a multi packet read", ErrProtocol)
+					ec.log().Errorf("package processing error: %v", err)
 					//continue
 					return nil, err
 				}
+				if ec.skipStreams(glow) {
+					continue
+				}
 				return glow, nil
 			}
 		}
@@ -135,42 +166,50 @@ func (ec *EmberClient) Receive() ([]byte, error) {
 func (ec *EmberClient) GetRoot() ([]byte, error) {
 	data, err := ec.GetByType("qualified_node", "")
 	if err != nil {
-		logger.Error("error getting Ember root request.", err)
+		ec.log().Errorf("error getting Ember root request: %v", err)
 		return nil, err
 	}
 	return data, nil
 }
 
 func (ec *EmberClient) GetByType(emberType ember.ElementType, emberPath string) ([]byte, error) {
+	el2, err := ec.getCollection(emberType, emberPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := el2.MarshalJSON()
+	if err != nil {
+		ec.log().Errorf("error marshalling Ember answer to JSON. Type: %v, Path: %v, %v", emberType, emberPath, err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// getCollection requests the element with the given type and path and returns the decoded answer.
+func (ec *EmberClient) getCollection(emberType ember.ElementType, emberPath string) (ember.ElementCollection, error) {
 	if !ec.IsConnected() {
 		return nil, errors.New("not connected")
-	} else {
-		tr, err := ember.GetRequestByType(emberType, emberPath)
-		if err != nil {
-			logger.Errorf("error getting Ember request. Type: %v, Path: %v, %v", emberType, emberPath, err)
-			return nil, err
-		}
-		ec.Write(tr)
-		out, err := ec.Receive()
-		if err != nil {
-			logger.Errorf("error getting Ember answer. Type: %v, Path: %v, %v", emberType, emberPath, err)
-			cerr := ec.conn.Close()
-			if cerr != nil {
-				logger.Errorf("Error while disconnecting Ember from %v: %v", ec.raddr, cerr)
-			}
-			return nil, err
-		}
-		el2 := ember.NewElementConnection()
-		err = el2.Populate(asn1.NewDecoder(out))
-		if err != nil {
-			logger.Errorf("error processing Ember answer. Type: %v, Path: %v, %v", emberType, emberPath, err)
-			return nil, err
-		}
-		data, err := el2.MarshalJSON()
-		if err != nil {
-			logger.Errorf("error marshalling Ember answer to JSON. Type: %v, Path: %v, %v", emberType, emberPath, err)
-			return nil, err
+	}
+	tr, err := ember.GetRequestByType(emberType, emberPath)
+	if err != nil {
+		ec.log().Errorf("error getting Ember request. Type: %v, Path: %v, %v", emberType, emberPath, err)
+		return nil, err
+	}
+	ec.Write(tr)
+	out, err := ec.Receive()
+	if err != nil {
+		ec.log().Errorf("error getting Ember answer. Type: %v, Path: %v, %v", emberType, emberPath, err)
+		cerr := ec.conn.Close()
+		if cerr != nil {
+			ec.log().Errorf("Error while disconnecting Ember from %v: %v", ec.raddr, cerr)
 		}
-		return data, nil
+		return nil, err
+	}
+	el2 := ember.NewElementCollection()
+	err = el2.Populate(asn1.NewDecoder(out))
+	if err != nil {
+		ec.log().Errorf("error processing Ember answer. Type: %v, Path: %v, %v", emberType, emberPath, err)
+		return nil, classifyGlowError(out, err)
 	}
+	return el2, nil
 }
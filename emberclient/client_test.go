@@ -1,6 +1,7 @@
 package emberclient
 
 import (
+	"errors"
 	"net"
 	"testing"
 
@@ -54,7 +55,7 @@ func TestConnectCannotConnectReturnsError(t *testing.T) {
 	err := ec.Connect()
 	assert.NotNil(t, err)
 	assert.EqualValues(t, false, ec.IsConnected())
-	assert.EqualValues(t, "dial tcp 127.0.0.1:9000: connectex: No connection could be made because the target machine actively refused it.", err.Error())
+	assert.True(t, errors.Is(err, ErrConnectionRefused))
 }
 
 func TestConnectCanConnectReturnsNoError(t *testing.T) {
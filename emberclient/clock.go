@@ -0,0 +1,123 @@
+package emberclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers to clients, DNS caches, coalescers and supervisors, so round trip times,
+// dial metrics, cache expiry, flush intervals and restart delays can be tested deterministically. Connection deadlines
+// are always enforced by the network stack on the system clock.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel receiving the time once d has elapsed on the clock.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock used by default.
+type systemClock struct{}
+
+// Now returns the system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits on the system timer.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// ManualClock is a Clock only moving when told to, for tests. Timers fire when the clock is moved past them.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []manualTimer
+}
+
+// manualTimer is a pending After call of a manual clock.
+type manualTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+// NewManualClock creates a manual clock set to now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time the clock is set to.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns a channel receiving the clock time once the clock was moved by d or more.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := manualTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+
+		return t.c
+	}
+
+	c.waiters = append(c.waiters, t)
+
+	return t.c
+}
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.fire()
+	c.mu.Unlock()
+}
+
+// Set sets the clock to now.
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	c.now = now
+	c.fire()
+	c.mu.Unlock()
+}
+
+// fire sends the time to all timers due, the caller must hold the lock.
+func (c *ManualClock) fire() {
+	pending := c.waiters[:0]
+
+	for _, t := range c.waiters {
+		if c.now.Before(t.at) {
+			pending = append(pending, t)
+
+			continue
+		}
+
+		t.c <- c.now
+	}
+
+	c.waiters = pending
+}
+
+// SetClock replaces the clock used for round trip times and dial metrics.
+func (ec *EmberClient) SetClock(clock Clock) {
+	ec.clock = clock
+}
+
+// now returns the time of the client clock.
+func (ec *EmberClient) now() time.Time {
+	if ec.clock == nil {
+		return time.Now()
+	}
+
+	return ec.clock.Now()
+}
+
+// since returns the time elapsed since t on the client clock.
+func (ec *EmberClient) since(t time.Time) time.Duration {
+	return ec.now().Sub(t)
+}
@@ -0,0 +1,87 @@
+package emberclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualClockAdvanceMovesTime(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := NewManualClock(start)
+
+	c.Advance(90 * time.Second)
+	assert.EqualValues(t, start.Add(90*time.Second), c.Now())
+
+	c.Set(start)
+	assert.EqualValues(t, start, c.Now())
+}
+
+func TestManualClockAfterFiresWhenAdvanced(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := NewManualClock(start)
+	timer := c.After(time.Minute)
+
+	c.Advance(59 * time.Second)
+	select {
+	case <-timer:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	c.Advance(time.Second)
+	assert.EqualValues(t, start.Add(time.Minute), <-timer)
+	assert.EqualValues(t, start.Add(time.Minute), <-c.After(0))
+}
+
+// waitForTimers waits until n timers are pending on the clock, so advancing it fires them.
+func waitForTimers(t *testing.T, c *ManualClock, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for {
+		c.mu.Lock()
+		pending := len(c.waiters)
+		c.mu.Unlock()
+
+		if pending >= n {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("%d timers pending, want %d", pending, n)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDNSCacheLookupExpiresWithClock(t *testing.T) {
+	var lookups int
+	clock := NewManualClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	c := stubDNSCache(&lookups, "10.0.0.1")
+	c.SetClock(clock)
+
+	c.Lookup(context.Background(), "mixer")
+	clock.Advance(59 * time.Second)
+	_, hit, _ := c.Lookup(context.Background(), "mixer")
+	assert.True(t, hit)
+
+	clock.Advance(time.Second)
+	_, hit, _ = c.Lookup(context.Background(), "mixer")
+	assert.False(t, hit)
+	assert.EqualValues(t, 2, lookups)
+}
+
+func TestClientSinceUsesClock(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	ec, _ := NewEmberClient("127.0.0.1", 9000)
+	ec.SetClock(clock)
+
+	start := ec.now()
+	clock.Advance(250 * time.Millisecond)
+	assert.EqualValues(t, 250*time.Millisecond, ec.since(start))
+}
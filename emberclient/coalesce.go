@@ -0,0 +1,148 @@
+package emberclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+// Coalescer reduces rapid repeated writes to the same parameter, e.g. from a fader, to the latest value per flush
+// interval. Coalescing is opt-in per path, writes to other paths are sent immediately. It is safe for concurrent use
+// and serializes all writes to the client, which must not be used by others meanwhile.
+type Coalescer struct {
+	ec       *EmberClient
+	interval time.Duration
+	mu       sync.Mutex
+	enabled  map[string]bool
+	pending  map[string]any
+	clock    Clock
+	writeMu  sync.Mutex
+}
+
+// NewCoalescer creates a coalescer writing to ec, Run flushes coalesced writes every interval.
+func NewCoalescer(ec *EmberClient, interval time.Duration) *Coalescer {
+	return &Coalescer{
+		ec:       ec,
+		interval: interval,
+		enabled:  make(map[string]bool),
+		pending:  make(map[string]any),
+		clock:    systemClock{},
+	}
+}
+
+// SetClock replaces the clock timing the flushes of Run.
+func (c *Coalescer) SetClock(clock Clock) {
+	c.mu.Lock()
+	c.clock = clock
+	c.mu.Unlock()
+}
+
+// Enable coalesces writes to the parameter at path.
+func (c *Coalescer) Enable(path string) {
+	c.mu.Lock()
+	c.enabled[normalizePath(path)] = true
+	c.mu.Unlock()
+}
+
+// Disable stops coalescing writes to the parameter at path, a pending write is kept until the next flush.
+func (c *Coalescer) Disable(path string) {
+	c.mu.Lock()
+	delete(c.enabled, normalizePath(path))
+	c.mu.Unlock()
+}
+
+// SetValue writes value to the parameter at path. For coalesced paths the value replaces any pending one and is
+// written by the next flush, otherwise it is written immediately.
+func (c *Coalescer) SetValue(ctx context.Context, path string, value any) error {
+	key := normalizePath(path)
+
+	c.mu.Lock()
+	if c.enabled[key] {
+		c.pending[key] = value
+		c.mu.Unlock()
+
+		return nil
+	}
+	c.mu.Unlock()
+
+	return c.write(ctx, key, value)
+}
+
+// Pending returns the number of writes waiting for the next flush.
+func (c *Coalescer) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending)
+}
+
+// Flush writes all pending values in numeric path order. Values which could not be written are dropped and returned as
+// PathErrors error.
+func (c *Coalescer) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string]any)
+	c.mu.Unlock()
+
+	paths := make([]string, 0, len(pending))
+	for p := range pending {
+		paths = append(paths, p)
+	}
+
+	ember.SortPaths(paths)
+
+	pathErrs := make(PathErrors)
+
+	for _, p := range paths {
+		err := c.write(ctx, p, pending[p])
+		if err != nil {
+			pathErrs[p] = err
+		}
+	}
+
+	if len(pathErrs) > 0 {
+		return pathErrs
+	}
+
+	return nil
+}
+
+// Run flushes pending writes every interval until ctx is cancelled, then flushes a last time with a fresh context
+// limited to one interval, so the final values are not lost but an unreachable provider does not block shutdown. Flush errors are passed to onError, which may be nil.
+func (c *Coalescer) Run(ctx context.Context, onError func(error)) {
+	for {
+		c.mu.Lock()
+		clock := c.clock
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), c.interval)
+			c.report(c.Flush(flushCtx), onError)
+			cancel()
+
+			return
+		case <-clock.After(c.interval):
+			c.report(c.Flush(ctx), onError)
+		}
+	}
+}
+
+// report passes err to onError, if both are set.
+func (c *Coalescer) report(err error, onError func(error)) {
+	if err != nil && onError != nil {
+		onError(err)
+	}
+}
+
+// write serializes a single write to the client.
+func (c *Coalescer) write(ctx context.Context, path string, value any) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_, err := c.ec.SetValue(ctx, path, value)
+
+	return err
+}
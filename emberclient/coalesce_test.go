@@ -0,0 +1,145 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/ember"
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalescerWritesLatestValueOnFlush(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	requests := make(chan []byte, 2)
+	go embertest.ServeMockProvider(provider, requests,
+		qualifiedIntParameter([]byte{1, 3}, "Trim", 1, accessReadWrite),
+		qualifiedIntParameter([]byte{1, 2}, "Gain", 9, accessReadWrite),
+	)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	c := NewCoalescer(ec, time.Hour)
+	c.Enable("1/2")
+
+	for _, v := range []int{5, 7, 9} {
+		assert.Nil(t, c.SetValue(context.Background(), "1.2", v))
+	}
+	assert.Nil(t, c.SetValue(context.Background(), "1.3", 1))
+	assert.Equal(t, 1, c.Pending())
+	assert.Nil(t, c.Flush(context.Background()))
+	assert.Equal(t, 0, c.Pending())
+
+	immediate, _ := ember.GetSetValueRequest("1.3", 1)
+	coalesced, _ := ember.GetSetValueRequest("1.2", 9)
+	assert.Equal(t, immediate, <-requests)
+	assert.Equal(t, coalesced, <-requests)
+}
+
+func TestCoalescerFlushWritesInNumericPathOrder(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	requests := make(chan []byte, 2)
+	go embertest.ServeMockProvider(provider, requests,
+		qualifiedIntParameter([]byte{1, 9}, "Gain", 1, accessReadWrite),
+		qualifiedIntParameter([]byte{1, 10}, "Trim", 2, accessReadWrite),
+	)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	c := NewCoalescer(ec, time.Hour)
+	c.Enable("1.9")
+	c.Enable("1.10")
+	assert.Nil(t, c.SetValue(context.Background(), "1.10", 2))
+	assert.Nil(t, c.SetValue(context.Background(), "1.9", 1))
+
+	assert.Nil(t, c.Flush(context.Background()))
+	first, _ := ember.GetSetValueRequest("1.9", 1)
+	second, _ := ember.GetSetValueRequest("1.10", 2)
+	assert.Equal(t, first, <-requests)
+	assert.Equal(t, second, <-requests)
+}
+
+func TestCoalescerRunFlushesEveryInterval(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	requests := make(chan []byte, 1)
+	go embertest.ServeMockProvider(provider, requests, qualifiedIntParameter([]byte{1, 2}, "Gain", 4, accessReadWrite))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	c := NewCoalescer(ec, time.Second)
+	c.SetClock(clock)
+	c.Enable("1.2")
+	assert.Nil(t, c.SetValue(context.Background(), "1.2", 4))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		c.Run(ctx, nil)
+		close(done)
+	}()
+	waitForTimers(t, clock, 1)
+	clock.Advance(time.Second)
+
+	want, _ := ember.GetSetValueRequest("1.2", 4)
+	assert.Equal(t, want, <-requests)
+	cancel()
+	<-done
+	assert.Equal(t, 0, c.Pending())
+}
+
+func TestCoalescerRunFlushesOnCancel(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	requests := make(chan []byte, 1)
+	go embertest.ServeMockProvider(provider, requests, qualifiedIntParameter([]byte{1, 2}, "Gain", 4, accessReadWrite))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	c := NewCoalescer(ec, time.Hour)
+	c.Enable("1.2")
+	assert.Nil(t, c.SetValue(context.Background(), "1.2", 4))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var errs []error
+	c.Run(ctx, func(err error) { errs = append(errs, err) })
+
+	assert.Empty(t, errs)
+	want, _ := ember.GetSetValueRequest("1.2", 4)
+	assert.Equal(t, want, <-requests)
+}
+
+func TestCoalescerFlushFailureReturnsPathErrors(t *testing.T) {
+	c := NewCoalescer(&EmberClient{}, time.Hour)
+	c.Enable("1.2")
+	assert.Nil(t, c.SetValue(context.Background(), "1.2", 4))
+
+	err := c.Flush(context.Background())
+	assert.IsType(t, PathErrors{}, err)
+	assert.Equal(t, 0, c.Pending())
+}
+
+func TestCoalescerRunReturnsIfFinalFlushIsNotConfirmed(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go io.Copy(io.Discard, provider)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	c := NewCoalescer(ec, 50*time.Millisecond)
+	c.Enable("1.2")
+	assert.Nil(t, c.SetValue(context.Background(), "1.2", 4))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var errs []error
+	c.Run(ctx, func(err error) { errs = append(errs, err) })
+
+	assert.Len(t, errs, 1)
+	var pathErrs PathErrors
+	assert.True(t, errors.As(errs[0], &pathErrs))
+	assert.NotNil(t, pathErrs["1.2"])
+}
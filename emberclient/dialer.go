@@ -0,0 +1,167 @@
+package emberclient
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// DNSCache caches the addresses of device host names for a fixed time, so reconnects do not wait for slow or flaky
+// DNS servers. A cache can be shared by many clients.
+type DNSCache struct {
+	ttl        time.Duration
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	clock      Clock
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+// dnsEntry holds the resolved addresses of a host name.
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// DialMetrics describes the last connection attempt of a client.
+type DialMetrics struct {
+	// Address is the address dialed last, with the host name resolved if a DNS cache is used.
+	Address string
+	// Resolve is the time spent resolving the host name, zero without DNS cache.
+	Resolve time.Duration
+	// CacheHit is true if the address was taken from the DNS cache.
+	CacheHit bool
+	// Connect is the time spent establishing the TCP connection, including failed attempts to other addresses.
+	Connect time.Duration
+	// Err is the error of the attempt, if any.
+	Err error
+}
+
+// NewDNSCache creates a DNS cache keeping resolutions for ttl.
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	return &DNSCache{
+		ttl:        ttl,
+		lookupHost: net.DefaultResolver.LookupHost,
+		clock:      systemClock{},
+		entries:    make(map[string]dnsEntry),
+	}
+}
+
+// Lookup returns the addresses of host, from the cache if not expired. The returned bool is true on cache hits.
+// IP addresses are returned unchanged, a host without addresses is an error.
+func (c *DNSCache) Lookup(ctx context.Context, host string) ([]string, bool, error) {
+	if net.ParseIP(host) != nil {
+		return []string{host}, false, nil
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	now := c.clock.Now()
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		return entry.addrs, true, nil
+	}
+
+	addrs, err := c.lookupHost(ctx, host)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, false, &net.DNSError{Err: "no addresses found", Name: host, IsNotFound: true}
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: c.clock.Now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return addrs, false, nil
+}
+
+// SetClock replaces the clock used to expire cached resolutions.
+func (c *DNSCache) SetClock(clock Clock) {
+	c.mu.Lock()
+	c.clock = clock
+	c.mu.Unlock()
+}
+
+// Forget removes host from the cache, e.g. after the cached address could not be reached.
+func (c *DNSCache) Forget(host string) {
+	c.mu.Lock()
+	delete(c.entries, host)
+	c.mu.Unlock()
+}
+
+// UseDNSCache makes Connect resolve the device host name with the provided cache.
+func (ec *EmberClient) UseDNSCache(c *DNSCache) {
+	ec.dnsCache = c
+}
+
+// LastDial returns the metrics of the last connection attempt.
+func (ec *EmberClient) LastDial() DialMetrics {
+	return ec.lastDial
+}
+
+// dial connects to the device, resolving its host name with the DNS cache if set, and records the dial metrics. All
+// resolved addresses are tried in order, the cached resolution is dropped if none of them could be reached.
+func (ec *EmberClient) dial() (net.Conn, error) {
+	var m DialMetrics
+
+	addrs := []string{ec.raddr}
+
+	host, port, err := net.SplitHostPort(ec.raddr)
+	if ec.dnsCache != nil {
+		if err != nil {
+			m.Err = err
+			ec.lastDial = m
+
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		start := ec.now()
+		resolved, hit, err := ec.dnsCache.Lookup(ctx, host)
+		m.Resolve = ec.since(start)
+		m.CacheHit = hit
+
+		cancel()
+
+		if err != nil {
+			m.Err = err
+			ec.lastDial = m
+
+			return nil, err
+		}
+
+		addrs = addrs[:0]
+		for _, a := range resolved {
+			addrs = append(addrs, net.JoinHostPort(a, port))
+		}
+	}
+
+	var conn net.Conn
+
+	start := ec.now()
+
+	for _, addr := range addrs {
+		m.Address = addr
+
+		conn, err = net.DialTimeout("tcp", addr, dialTimeout)
+		if err == nil {
+			break
+		}
+	}
+
+	m.Connect = ec.since(start)
+	m.Err = err
+
+	if err != nil && ec.dnsCache != nil {
+		ec.dnsCache.Forget(host)
+	}
+
+	ec.lastDial = m
+
+	return conn, err
+}
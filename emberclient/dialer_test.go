@@ -0,0 +1,152 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDNSCache returns a DNS cache resolving every host to addrs, counting the lookups.
+func stubDNSCache(lookups *int, addrs ...string) *DNSCache {
+	c := NewDNSCache(time.Minute)
+	c.lookupHost = func(_ context.Context, host string) ([]string, error) {
+		*lookups++
+		if len(addrs) == 0 {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+
+		return addrs, nil
+	}
+
+	return c
+}
+
+func TestDNSCacheLookupCachesAddresses(t *testing.T) {
+	var lookups int
+	c := stubDNSCache(&lookups, "10.0.0.1")
+
+	addrs, hit, err := c.Lookup(context.Background(), "mixer")
+	assert.Nil(t, err)
+	assert.False(t, hit)
+	assert.EqualValues(t, []string{"10.0.0.1"}, addrs)
+
+	addrs, hit, err = c.Lookup(context.Background(), "mixer")
+	assert.Nil(t, err)
+	assert.True(t, hit)
+	assert.EqualValues(t, []string{"10.0.0.1"}, addrs)
+	assert.EqualValues(t, 1, lookups)
+
+	c.Forget("mixer")
+	_, hit, _ = c.Lookup(context.Background(), "mixer")
+	assert.False(t, hit)
+	assert.EqualValues(t, 2, lookups)
+}
+
+func TestDNSCacheLookupIPReturnsIP(t *testing.T) {
+	var lookups int
+	c := stubDNSCache(&lookups, "10.0.0.1")
+
+	addrs, _, err := c.Lookup(context.Background(), "192.168.1.5")
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"192.168.1.5"}, addrs)
+	assert.EqualValues(t, 0, lookups)
+}
+
+func TestConnectWithDNSCacheRecordsMetrics(t *testing.T) {
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	defer l.Close()
+	port := l.Addr().(*net.TCPAddr).Port
+	var lookups int
+	ec, _ := NewEmberClient("mixer.studio", port)
+	ec.UseDNSCache(stubDNSCache(&lookups, "127.0.0.1"))
+
+	err := ec.Connect()
+	assert.Nil(t, err)
+	assert.EqualValues(t, "127.0.0.1:"+strconv.Itoa(port), ec.LastDial().Address)
+	assert.False(t, ec.LastDial().CacheHit)
+	ec.Disconnect()
+
+	err = ec.Connect()
+	assert.Nil(t, err)
+	assert.True(t, ec.LastDial().CacheHit)
+	assert.EqualValues(t, 1, lookups)
+	ec.Disconnect()
+}
+
+func TestConnectWithDNSCacheUnknownHostReturnsErrDNS(t *testing.T) {
+	var lookups int
+	ec, _ := NewEmberClient("unknown.studio", 9000)
+	ec.UseDNSCache(stubDNSCache(&lookups))
+
+	err := ec.Connect()
+	assert.True(t, errors.Is(err, ErrDNS))
+	assert.NotNil(t, ec.LastDial().Err)
+	assert.False(t, ec.IsConnected())
+}
+
+func TestConnectWithDNSCacheFallsBackToNextAddress(t *testing.T) {
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	defer l.Close()
+	port := l.Addr().(*net.TCPAddr).Port
+	var lookups int
+	ec, _ := NewEmberClient("mixer.studio", port)
+	ec.UseDNSCache(stubDNSCache(&lookups, "127.0.0.2", "127.0.0.1"))
+
+	err := ec.Connect()
+	assert.Nil(t, err)
+	assert.EqualValues(t, "127.0.0.1:"+strconv.Itoa(port), ec.LastDial().Address)
+	ec.Disconnect()
+
+	err = ec.Connect()
+	assert.Nil(t, err)
+	assert.True(t, ec.LastDial().CacheHit)
+	assert.EqualValues(t, 1, lookups)
+	ec.Disconnect()
+}
+
+func TestConnectWithDNSCacheForgetsUnreachableAddress(t *testing.T) {
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	var lookups int
+	ec, _ := NewEmberClient("mixer.studio", port)
+	ec.UseDNSCache(stubDNSCache(&lookups, "127.0.0.1"))
+
+	assert.NotNil(t, ec.Connect())
+	assert.NotNil(t, ec.Connect())
+	assert.False(t, ec.LastDial().CacheHit)
+	assert.EqualValues(t, 2, lookups)
+}
+
+func TestConnectWithDNSCacheWithoutAddressesReturnsErrDNS(t *testing.T) {
+	c := NewDNSCache(time.Minute)
+	c.lookupHost = func(context.Context, string) ([]string, error) {
+		return nil, nil
+	}
+	ec, _ := NewEmberClient("mixer.studio", 9000)
+	ec.UseDNSCache(c)
+
+	err := ec.Connect()
+	assert.ErrorIs(t, err, ErrDNS)
+	assert.False(t, ec.IsConnected())
+}
+
+func TestDNSCacheSetClockWhileLookingUp(t *testing.T) {
+	var lookups int
+	c := stubDNSCache(&lookups, "10.0.0.1")
+	done := make(chan struct{})
+
+	go func() {
+		c.SetClock(NewManualClock(time.Unix(1700000000, 0)))
+		close(done)
+	}()
+	_, _, err := c.Lookup(context.Background(), "mixer.studio")
+	<-done
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,39 @@
+// Package embertest provides a mock Ember+ provider for tests and examples of code using emberclient.
+package embertest
+
+import (
+	"net"
+
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+// ServeMockProvider answers requests read from conn the way an Ember+ provider does, until conn is closed. Keep alive
+// requests get a keep alive response, every other request the next glow payload wrapped in a single S101 packet and
+// is passed on to requests, if not nil. Requests arriving after the last payload stay unanswered.
+func ServeMockProvider(conn net.Conn, requests chan<- []byte, responses ...[]byte) {
+	for {
+		buf := make([]byte, 1290)
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+
+		if s101.IsKeepAliveRequest(buf[:n]) {
+			conn.Write(s101.EncodeKeepAliveResponse())
+
+			continue
+		}
+
+		if len(responses) == 0 {
+			continue
+		}
+
+		if requests != nil {
+			requests <- buf[:n]
+		}
+
+		conn.Write(s101.Encode(responses[0], s101.SinglePacket))
+		responses = responses[1:]
+	}
+}
@@ -0,0 +1,56 @@
+package emberclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+// Errors returned by the client, so monitoring can tell failure causes apart using errors.Is. The original error
+// stays wrapped as well.
+var (
+	// ErrDNS is returned when the host name of the device can not be resolved.
+	ErrDNS = errors.New("dns lookup failed")
+	// ErrConnectionRefused is returned when the device actively refused the TCP connection.
+	ErrConnectionRefused = errors.New("connection refused")
+	// ErrHandshakeTimeout is returned when the TCP connection could not be established in time.
+	ErrHandshakeTimeout = errors.New("handshake timeout")
+	// ErrProtocol is returned when the device sent data that is not valid S101 or Glow.
+	ErrProtocol = errors.New("protocol error")
+	// ErrElementNotFound is returned when the requested element is not part of the answer.
+	ErrElementNotFound = ember.ErrElementNotFound
+	// ErrRemoteGlow is returned when the device answered with a Glow message other than the requested elements,
+	// e.g. an invocation result.
+	ErrRemoteGlow = errors.New("remote glow error")
+)
+
+// classifyDialError wraps a dial error with the matching client error.
+func classifyDialError(err error) error {
+	var (
+		dnsErr *net.DNSError
+		netErr net.Error
+	)
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("%w: %w", ErrDNS, err)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return fmt.Errorf("%w: %w", ErrConnectionRefused, err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("%w: %w", ErrHandshakeTimeout, err)
+	default:
+		return err
+	}
+}
+
+// classifyGlowError wraps an error decoding a glow answer, telling remote errors apart from malformed data.
+func classifyGlowError(glow []byte, err error) error {
+	if ember.ClassifyMessage(glow) == ember.MessageInvocationResult {
+		return fmt.Errorf("%w: %w", ErrRemoteGlow, err)
+	}
+
+	return fmt.Errorf("%w: %w", ErrProtocol, err)
+}
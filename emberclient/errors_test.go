@@ -0,0 +1,70 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyDialErrorDNSReturnsErrDNS(t *testing.T) {
+	err := classifyDialError(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "device.invalid"}})
+	assert.True(t, errors.Is(err, ErrDNS))
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(err, &dnsErr))
+}
+
+func TestClassifyDialErrorRefusedReturnsErrConnectionRefused(t *testing.T) {
+	err := classifyDialError(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)})
+	assert.True(t, errors.Is(err, ErrConnectionRefused))
+}
+
+func TestClassifyDialErrorTimeoutReturnsErrHandshakeTimeout(t *testing.T) {
+	err := classifyDialError(&net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded})
+	assert.True(t, errors.Is(err, ErrHandshakeTimeout))
+}
+
+func TestClassifyDialErrorOtherReturnsError(t *testing.T) {
+	err := classifyDialError(errors.New("other"))
+	assert.EqualValues(t, "other", err.Error())
+}
+
+func TestGetByTypeInvocationResultReturnsErrRemoteGlow(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, tlv(0x60, tlv(0x77, tlv(0xA0, tlv(0x02, []byte{0x01})), tlv(0xA1, tlv(0x01, []byte{0x00})))))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	_, err := ec.GetByType("qualified_parameter", "1.2.1")
+	assert.True(t, errors.Is(err, ErrRemoteGlow))
+}
+
+func TestGetByTypeMalformedReturnsErrProtocol(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, tlv(0x60, tlv(0x6B, tlv(0xA0, tlv(0x02, []byte{0x01})))))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	_, err := ec.GetByType("qualified_parameter", "1.2.1")
+	assert.True(t, errors.Is(err, ErrProtocol))
+}
+
+func TestGetValuesMissingElementReturnsErrElementNotFound(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, qualifiedIntParameter([]byte{1, 2, 9}, "Other", 1, accessRead))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	_, err := ec.GetValues(context.Background(), []string{"1.2.1"})
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.True(t, errors.Is(pathErrs["1.2.1"], ErrElementNotFound))
+}
@@ -0,0 +1,87 @@
+package emberclient_test
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/johannes-kuhfuss/emberplus/emberclient"
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+)
+
+// rootGlow is the answer of a provider to a root GetDirectory request, holding the single node "1".
+//
+//nolint:gochecknoglobals
+var rootGlow = []byte{
+	0x60, 0x34, 0x6B, 0x32, 0xA0, 0x30, 0x63, 0x2E, 0xA0, 0x03, 0x02, 0x01, 0x01, 0xA1, 0x27, 0x31,
+	0x25, 0xA0, 0x16, 0x0C, 0x14, 0x52, 0x33, 0x4C, 0x41, 0x59, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61,
+	0x6C, 0x50, 0x61, 0x74, 0x63, 0x68, 0x42, 0x61, 0x79, 0xA1, 0x02, 0x0C, 0x00, 0xA4, 0x02, 0x0C,
+	0x00, 0xA3, 0x03, 0x01, 0x01, 0xFF,
+}
+
+func ExampleNewEmberClient() {
+	// a mock provider listening on a local port stands in for the device, e.g. "192.168.0.10" port 9000
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		embertest.ServeMockProvider(conn, nil, rootGlow)
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+
+	ec, err := emberclient.NewEmberClient(addr.IP.String(), addr.Port)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	err = ec.Connect()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	defer ec.Disconnect()
+
+	data, err := ec.GetRoot()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(string(data))
+	// Output: {"1":{"path":"1","element_type":"node","children":null,"identifier":"R3LAYVirtualPatchBay","description":"","is_online":true,"is_root":false}}
+}
+
+func ExampleEmberClient_GetRoot() {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+
+	go embertest.ServeMockProvider(provider, nil, rootGlow)
+
+	ec := emberclient.NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	data, err := ec.GetRoot()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(string(data))
+	// Output: {"1":{"path":"1","element_type":"node","children":null,"identifier":"R3LAYVirtualPatchBay","description":"","is_online":true,"is_root":false}}
+}
@@ -0,0 +1,192 @@
+//go:build integration
+
+package emberclient
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/ember"
+	"github.com/stretchr/testify/assert"
+)
+
+// The integration tests run against real Ember+ providers and are only built with the integration tag. Every
+// provider listed in EMBER_PROVIDERS is tested, e.g. a device in the lab next to the reference provider:
+//
+//	EMBER_PROVIDERS="emberplus-connection=localhost:9000,mixer=192.168.0.10:9000" go test -tags integration ./emberclient
+//
+// EMBER_SET_PATH optionally names a writable integer parameter present on every provider, which is set to its
+// current value plus one and back.
+//
+// "make integration" builds and starts the emberplus-connection reference provider of testdata/integration with
+// docker compose, runs the tests against it and removes the container again.
+
+const (
+	integrationTimeout = 10 * time.Second
+	// crawlLimit is the maximum number of directories requested by the crawl test.
+	crawlLimit = 100
+)
+
+// integrationProvider is a provider under test, as configured in EMBER_PROVIDERS.
+type integrationProvider struct {
+	name string
+	host string
+	port int
+}
+
+// integrationProviders parses EMBER_PROVIDERS and skips the test if it is not set.
+func integrationProviders(t *testing.T) []integrationProvider {
+	t.Helper()
+
+	env := os.Getenv("EMBER_PROVIDERS")
+	if env == "" {
+		t.Skip("EMBER_PROVIDERS not set")
+	}
+
+	var out []integrationProvider
+
+	for _, entry := range strings.Split(env, ",") {
+		name, addr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			name, addr = entry, entry
+		}
+
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			t.Fatalf("invalid provider %q: %v", entry, err)
+		}
+
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			t.Fatalf("invalid provider port %q: %v", entry, err)
+		}
+
+		out = append(out, integrationProvider{name: name, host: host, port: port})
+	}
+
+	return out
+}
+
+// connect connects a client to the provider, it is disconnected when the test ends.
+func (p integrationProvider) connect(t *testing.T) *EmberClient {
+	t.Helper()
+
+	ec, err := NewEmberClient(p.host, p.port)
+	if err != nil {
+		t.Fatalf("NewEmberClient() error = %v", err)
+	}
+
+	err = ec.Connect()
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	t.Cleanup(func() { ec.Disconnect() })
+
+	return ec
+}
+
+func TestIntegrationProbeReturnsRootElements(t *testing.T) {
+	for _, p := range integrationProviders(t) {
+		t.Run(p.name, func(t *testing.T) {
+			ec := p.connect(t)
+			ctx, cancel := context.WithTimeout(context.Background(), integrationTimeout)
+			defer cancel()
+
+			res, err := ec.Probe(ctx)
+			assert.Nil(t, err)
+			assert.Positive(t, res.Nodes+res.Parameters+res.Functions+res.Matrices)
+		})
+	}
+}
+
+func TestIntegrationGetRootReturnsJSON(t *testing.T) {
+	for _, p := range integrationProviders(t) {
+		t.Run(p.name, func(t *testing.T) {
+			ec := p.connect(t)
+
+			data, err := ec.GetRoot()
+			assert.Nil(t, err)
+			assert.NotEmpty(t, data)
+		})
+	}
+}
+
+func TestIntegrationSetValueRoundTrips(t *testing.T) {
+	path := os.Getenv("EMBER_SET_PATH")
+	providers := integrationProviders(t)
+	if path == "" {
+		t.Skip("EMBER_SET_PATH not set")
+	}
+
+	for _, p := range providers {
+		t.Run(p.name, func(t *testing.T) {
+			ec := p.connect(t)
+			ctx, cancel := context.WithTimeout(context.Background(), integrationTimeout)
+			defer cancel()
+
+			values, err := ec.GetValues(ctx, []string{path})
+			assert.Nil(t, err)
+			original, ok := asInt64(values[path])
+			if !ok {
+				t.Fatalf("value of %s is %T, want an integer", path, values[path])
+			}
+
+			confirmed, err := ec.SetValue(ctx, path, original+1)
+			assert.Nil(t, err)
+			assert.True(t, sameValue(original+1, confirmed))
+
+			confirmed, err = ec.SetValue(ctx, path, original)
+			assert.Nil(t, err)
+			assert.True(t, sameValue(original, confirmed))
+		})
+	}
+}
+
+func TestIntegrationCrawlReachesParameters(t *testing.T) {
+	for _, p := range integrationProviders(t) {
+		t.Run(p.name, func(t *testing.T) {
+			ec := p.connect(t)
+			queue := []string{""}
+			seen := map[string]bool{"": true}
+			parameters := 0
+
+			for requests := 0; len(queue) > 0 && requests < crawlLimit; requests++ {
+				path := queue[0]
+				queue = queue[1:]
+
+				coll, err := ec.getCollection(asn1.QualifiedNodeType, path)
+				if err != nil {
+					t.Fatalf("getCollection(%q) error = %v", path, err)
+				}
+
+				for key, el := range coll {
+					elements := map[string]*ember.Element{key.Path: el}
+					for _, ch := range el.Children {
+						elements[key.Path+"."+ch.Path] = ch
+					}
+
+					for elPath, found := range elements {
+						switch found.ElementType {
+						case asn1.NodeType, asn1.QualifiedNodeType:
+							if !seen[elPath] {
+								seen[elPath] = true
+								queue = append(queue, elPath)
+							}
+						case asn1.ParameterType, asn1.QualifiedParameterType:
+							parameters++
+						}
+					}
+				}
+			}
+
+			assert.Positive(t, parameters)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package emberclient
+
+import "log"
+
+// Logger receives the log messages of a client. Unless a logger is set, clients log errors through the standard library
+// logger and discard all other messages, the servicesutils module provides an adapter for the services_utils logger.
+type Logger interface {
+	Debugf(msg string, a ...any)
+	Infof(msg string, a ...any)
+	Errorf(msg string, a ...any)
+}
+
+// nopLogger discards all messages.
+type nopLogger struct{}
+
+// Debugf discards the message.
+func (nopLogger) Debugf(string, ...any) {}
+
+// Infof discards the message.
+func (nopLogger) Infof(string, ...any) {}
+
+// Errorf discards the message.
+func (nopLogger) Errorf(string, ...any) {}
+
+// errorLogger logs messages with error level to a standard library logger and discards all other messages.
+type errorLogger struct {
+	nopLogger
+	l *log.Logger
+}
+
+// Errorf logs the message.
+func (e errorLogger) Errorf(msg string, a ...any) {
+	e.l.Printf("ERROR "+msg, a...)
+}
+
+// defaultLogger is used by clients without a logger set.
+//
+//nolint:gochecknoglobals
+var defaultLogger Logger = errorLogger{l: log.Default()}
+
+// SetLogger replaces the logger of the client, nil restores the default logger.
+func (ec *EmberClient) SetLogger(l Logger) {
+	ec.logger = l
+}
+
+// log returns the logger of the client.
+func (ec *EmberClient) log() Logger {
+	if ec.logger == nil {
+		return defaultLogger
+	}
+
+	return ec.logger
+}
@@ -0,0 +1,53 @@
+package emberclient
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger keeps all messages logged with error level.
+type recordingLogger struct {
+	nopLogger
+	errors []string
+}
+
+func (l *recordingLogger) Errorf(msg string, a ...any) {
+	l.errors = append(l.errors, fmt.Sprintf(msg, a...))
+}
+
+func TestSetLoggerReceivesClientMessages(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	l := &recordingLogger{}
+	ec.SetLogger(l)
+
+	err := ec.Connect()
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"Cannot connect Ember to pipe, already connected"}, l.errors)
+}
+
+func TestSetLoggerNilRestoresDefault(t *testing.T) {
+	ec := &EmberClient{}
+	ec.SetLogger(&recordingLogger{})
+	ec.SetLogger(nil)
+
+	assert.Equal(t, defaultLogger, ec.log())
+}
+
+func TestErrorLoggerLogsOnlyErrors(t *testing.T) {
+	var buf bytes.Buffer
+	l := errorLogger{l: log.New(&buf, "", 0)}
+
+	l.Debugf("debug %d", 1)
+	l.Infof("info %d", 2)
+	l.Errorf("error %d", 3)
+
+	assert.Equal(t, "ERROR error 3\n", buf.String())
+}
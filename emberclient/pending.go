@@ -0,0 +1,209 @@
+package emberclient
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+// WriteState is the state of a value written with SetValue.
+type WriteState int
+
+const (
+	// WritePending the value was written, but the provider did not confirm it yet.
+	WritePending WriteState = iota
+	// WriteConfirmed the provider reported the written value.
+	WriteConfirmed
+	// WriteFailed the request could not be sent or the provider did not report the written value before the timeout.
+	WriteFailed
+)
+
+// String returns the name of the state.
+func (s WriteState) String() string {
+	switch s {
+	case WritePending:
+		return "pending"
+	case WriteConfirmed:
+		return "confirmed"
+	case WriteFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("WriteState(%d)", int(s))
+	}
+}
+
+// PendingWrite is the state of the last value written to a parameter.
+type PendingWrite struct {
+	Path    string     `json:"path"`
+	Value   any        `json:"value"`
+	Written time.Time  `json:"written"`
+	State   WriteState `json:"state"`
+}
+
+// PendingWrites tracks values written with SetValue until the provider confirms them, so user interfaces can show
+// changes in flight. Writes not confirmed within the timeout are marked failed. It is safe for concurrent use.
+type PendingWrites struct {
+	timeout time.Duration
+	clock   Clock
+	mu      sync.Mutex
+	writes  map[string]*PendingWrite
+}
+
+// NewPendingWrites creates a tracker marking writes failed if they are not confirmed within timeout.
+func NewPendingWrites(timeout time.Duration) *PendingWrites {
+	return &PendingWrites{
+		timeout: timeout,
+		clock:   systemClock{},
+		writes:  make(map[string]*PendingWrite),
+	}
+}
+
+// SetClock replaces the clock used for the write timeout.
+func (pw *PendingWrites) SetClock(clock Clock) {
+	pw.clock = clock
+}
+
+// TrackWrites makes SetValue record its writes in pw and confirm them from the provider answer.
+func (ec *EmberClient) TrackWrites(pw *PendingWrites) {
+	ec.pendingWrites = pw
+}
+
+// Track records value as written to the parameter at path, replacing any earlier write to it.
+func (pw *PendingWrites) Track(path string, value any) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	path = normalizePath(path)
+	pw.writes[path] = &PendingWrite{Path: path, Value: value, Written: pw.clock.Now(), State: WritePending}
+}
+
+// Observe confirms pending writes, whose value is reported by the elements of the collection, e.g. an update
+// received by a read loop. Writes already timed out stay failed.
+func (pw *PendingWrites) Observe(coll ember.ElementCollection) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	pw.expire()
+
+	for path, w := range pw.writes {
+		if w.State != WritePending {
+			continue
+		}
+
+		m, err := ember.CompilePath(path)
+		if err != nil {
+			continue
+		}
+
+		el, err := coll.GetElementByMatcher(m)
+		if err != nil || !sameValue(w.Value, el.Value) {
+			continue
+		}
+
+		w.State = WriteConfirmed
+	}
+}
+
+// State returns the state of the last write to the parameter at path.
+func (pw *PendingWrites) State(path string) (PendingWrite, bool) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	pw.expire()
+
+	w, ok := pw.writes[normalizePath(path)]
+	if !ok {
+		return PendingWrite{}, false
+	}
+
+	return *w, true
+}
+
+// Writes returns the state of the last write to every tracked parameter, in path order.
+func (pw *PendingWrites) Writes() []PendingWrite {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	pw.expire()
+
+	paths := make([]string, 0, len(pw.writes))
+	for path := range pw.writes {
+		paths = append(paths, path)
+	}
+
+	ember.SortPaths(paths)
+
+	out := make([]PendingWrite, 0, len(paths))
+	for _, path := range paths {
+		out = append(out, *pw.writes[path])
+	}
+
+	return out
+}
+
+// Forget stops tracking the parameter at path.
+func (pw *PendingWrites) Forget(path string) {
+	pw.mu.Lock()
+	delete(pw.writes, normalizePath(path))
+	pw.mu.Unlock()
+}
+
+// fail marks the write to the parameter at path failed, e.g. because the request could not be sent.
+func (pw *PendingWrites) fail(path string) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if w, ok := pw.writes[normalizePath(path)]; ok && w.State == WritePending {
+		w.State = WriteFailed
+	}
+}
+
+// expire marks pending writes older than the timeout failed.
+func (pw *PendingWrites) expire() {
+	now := pw.clock.Now()
+
+	for _, w := range pw.writes {
+		if w.State == WritePending && now.Sub(w.Written) > pw.timeout {
+			w.State = WriteFailed
+		}
+	}
+}
+
+// normalizePath returns path in dotted notation, or unchanged if it can not be parsed.
+func normalizePath(path string) string {
+	m, err := ember.CompilePath(path)
+	if err != nil {
+		return path
+	}
+
+	return m.String()
+}
+
+// sameValue compares a written and a reported value, integers are compared regardless of their size.
+func sameValue(written any, reported any) bool {
+	a, aok := asInt64(written)
+	b, bok := asInt64(reported)
+
+	if aok && bok {
+		return a == b
+	}
+
+	return reflect.DeepEqual(written, reported)
+}
+
+// asInt64 returns v as int64 if it is an integer.
+func asInt64(v any) (int64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
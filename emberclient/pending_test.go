@@ -0,0 +1,95 @@
+package emberclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/ember"
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetValueTrackedWriteConfirmedByEcho(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, qualifiedIntParameter([]byte{1, 2}, "Gain", 7, accessReadWrite))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	pw := NewPendingWrites(time.Second)
+	ec.TrackWrites(pw)
+
+	_, err := ec.SetValue(context.Background(), "1/2", 7)
+	assert.Nil(t, err)
+	w, ok := pw.State("1.2")
+	assert.True(t, ok)
+	assert.Equal(t, WriteConfirmed, w.State)
+	assert.EqualValues(t, 7, w.Value)
+}
+
+func TestPendingWritesUnconfirmedWriteFailsAfterTimeout(t *testing.T) {
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	pw := NewPendingWrites(time.Second)
+	pw.SetClock(clock)
+	pw.Track("1.2", 7)
+	pw.Observe(ember.ElementCollection{
+		ember.ElementKey{Path: "1.2"}: &ember.Element{Path: "1.2", ElementType: asn1.QualifiedParameterType, Value: int64(6)},
+	})
+
+	w, _ := pw.State("1.2")
+	assert.Equal(t, WritePending, w.State)
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, []PendingWrite{{Path: "1.2", Value: 7, Written: time.Unix(1700000000, 0), State: WriteFailed}}, pw.Writes())
+	pw.Forget("1.2")
+	_, ok := pw.State("1.2")
+	assert.False(t, ok)
+}
+
+func TestSetValueTrackedWriteFailsIfRequestNotSent(t *testing.T) {
+	provider, consumer := net.Pipe()
+	provider.Close()
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	pw := NewPendingWrites(time.Second)
+	ec.TrackWrites(pw)
+
+	_, err := ec.SetValue(context.Background(), "1.2", 7)
+	assert.NotNil(t, err)
+	w, _ := pw.State("1.2")
+	assert.Equal(t, WriteFailed, w.State)
+}
+
+func TestPendingWritesObserveDoesNotConfirmTimedOutWrite(t *testing.T) {
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	pw := NewPendingWrites(time.Second)
+	pw.SetClock(clock)
+	pw.Track("1.2", 7)
+	clock.Advance(2 * time.Second)
+	pw.Observe(ember.ElementCollection{
+		ember.ElementKey{Path: "1.2"}: &ember.Element{Path: "1.2", ElementType: asn1.QualifiedParameterType, Value: int64(7)},
+	})
+
+	w, _ := pw.State("1.2")
+	assert.Equal(t, WriteFailed, w.State)
+}
+
+func TestPendingWritesWritesInNumericPathOrder(t *testing.T) {
+	pw := NewPendingWrites(time.Second)
+	pw.Track("1.10", 1)
+	pw.Track("1.9", 2)
+	pw.Track("1.2", 3)
+
+	var paths []string
+	for _, w := range pw.Writes() {
+		paths = append(paths, w.Path)
+	}
+
+	assert.Equal(t, []string{"1.2", "1.9", "1.10"}, paths)
+}
+
+func TestWriteStateStringReturnsName(t *testing.T) {
+	assert.Equal(t, "confirmed", WriteConfirmed.String())
+	assert.Equal(t, "WriteState(9)", WriteState(9).String())
+}
@@ -0,0 +1,171 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/ember"
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+const (
+	// defaultProbeTimeout is used for each probe step if the context has no deadline.
+	defaultProbeTimeout = 5 * time.Second
+	// keepAliveProbeTimeout limits how long Probe waits for devices that do not answer keep alive requests.
+	keepAliveProbeTimeout = time.Second
+)
+
+// ProbeResult summarizes what a device offers at its root, as detected by Probe.
+type ProbeResult struct {
+	// KeepAliveSupported is true if the device answered the S101 keep alive request.
+	KeepAliveSupported bool `json:"keep_alive_supported"`
+	// KeepAliveRTT is the round trip time of the keep alive request.
+	KeepAliveRTT time.Duration `json:"keep_alive_rtt"`
+	// GetDirectoryRTT is the time it took to receive the complete root directory.
+	GetDirectoryRTT time.Duration `json:"get_directory_rtt"`
+	// Nodes, Parameters, Functions and Matrices count the elements of each kind in the root directory.
+	Nodes      int `json:"nodes"`
+	Parameters int `json:"parameters"`
+	Functions  int `json:"functions"`
+	Matrices   int `json:"matrices"`
+	// HasStreams is true if a root parameter is streamed or the device sent stream data.
+	HasStreams bool `json:"has_streams"`
+}
+
+// Probe measures the keep alive round trip time and requests the root directory of the device, counting the kinds of
+// elements found there. Only the root level is inspected, deeper levels need to be crawled. Matrices are detected even
+// though the decoder does not support them yet. A device not answering keep alive requests is not an error.
+func (ec *EmberClient) Probe(ctx context.Context) (*ProbeResult, error) {
+	if !ec.IsConnected() {
+		return nil, errors.New("not connected")
+	}
+
+	var res ProbeResult
+
+	kaCtx, cancel := context.WithTimeout(ctx, keepAliveProbeTimeout)
+	rtt, err := ec.KeepAlive(kaCtx)
+	cancel()
+
+	if err == nil {
+		res.KeepAliveSupported = true
+		res.KeepAliveRTT = rtt
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	request, err := ember.GetRootRequest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create root request: %w", err)
+	}
+
+	ec.setDeadline(ctx)
+	defer ec.conn.SetDeadline(time.Time{})
+
+	start := ec.now()
+
+	_, err = ec.Write(request)
+	if err != nil {
+		return nil, err
+	}
+
+	glow, err := ec.Receive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive root directory: %w", err)
+	}
+
+	res.GetDirectoryRTT = ec.since(start)
+
+	err = asn1.Walk(glow, res.count)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to inspect root directory: %w", ErrProtocol, err)
+	}
+
+	return &res, nil
+}
+
+// KeepAlive sends a S101 keep alive request and waits for the response, returning the round trip time. Other packets
+// received while waiting are dropped.
+func (ec *EmberClient) KeepAlive(ctx context.Context) (time.Duration, error) {
+	if !ec.IsConnected() {
+		return 0, errors.New("not connected")
+	}
+
+	ec.setDeadline(ctx)
+	defer ec.conn.SetDeadline(time.Time{})
+
+	start := ec.now()
+
+	_, err := ec.Write(s101.EncodeKeepAliveRequest())
+	if err != nil {
+		return 0, err
+	}
+
+	var incomplete []byte
+
+	for {
+		buf := make([]byte, 1290)
+
+		n, err := ec.conn.Read(buf)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read keep alive response: %w", err)
+		}
+
+		frames, rest, err := s101.GetS101s(append(incomplete, buf[:n]...))
+		if err != nil {
+			return 0, fmt.Errorf("%w: failed to get s101 data from read: %w", ErrProtocol, err)
+		}
+
+		incomplete = rest
+
+		for _, frame := range frames {
+			if s101.IsKeepAliveResponse(frame) {
+				return ec.since(start), nil
+			}
+		}
+	}
+}
+
+// setDeadline applies the context deadline to the connection, or the default probe timeout if there is none.
+func (ec *EmberClient) setDeadline(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultProbeTimeout)
+	}
+
+	ec.conn.SetDeadline(deadline)
+}
+
+// count is an asn1.WalkFunc counting the element kinds of a root directory.
+func (res *ProbeResult) count(tag byte, ancestors []byte) error {
+	if asn1.IsApplication(tag) {
+		switch asn1.Tag(asn1.TagNumber(tag)) {
+		case asn1.TagNode, asn1.TagQualifiedNode:
+			res.Nodes++
+		case asn1.TagParameter, asn1.TagQualifiedParameter:
+			res.Parameters++
+		case asn1.TagFunction, asn1.TagQualifiedFunction:
+			res.Functions++
+		case asn1.TagMatrix, asn1.TagQualifiedMatrix:
+			res.Matrices++
+		case asn1.TagStreamCollection:
+			res.HasStreams = true
+		}
+
+		return nil
+	}
+
+	// streamIdentifier is context 14 in the contents set of a parameter: parameter, [1] contents, set.
+	if tag == asn1.ContextByte(14) && len(ancestors) >= 3 && ancestors[len(ancestors)-1] == asn1.SetTag {
+		param := asn1.Tag(asn1.TagNumber(ancestors[len(ancestors)-3]))
+		if param == asn1.TagParameter || param == asn1.TagQualifiedParameter {
+			res.HasStreams = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package emberclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/stretchr/testify/assert"
+)
+
+// rootDirectory is a glow root collection with a node, a streamed qualified parameter and a qualified matrix.
+func rootDirectory() []byte {
+	node := tlv(0x63, tlv(0xA0, tlv(0x02, []byte{0x01})))
+	streamed := tlv(0x69,
+		tlv(0xA0, tlv(0x0D, []byte{0x02})),
+		tlv(0xA1, tlv(0x31, tlv(0xAE, tlv(0x02, []byte{0x07})))),
+	)
+	matrix := tlv(0x71, tlv(0xA0, tlv(0x0D, []byte{0x03})))
+
+	return tlv(0x60, tlv(0x6B, tlv(0xA0, node), tlv(0xA0, streamed), tlv(0xA0, matrix)))
+}
+
+func TestProbeReturnsSummary(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, rootDirectory())
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	res, err := ec.Probe(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, res.KeepAliveSupported)
+	assert.EqualValues(t, 1, res.Nodes)
+	assert.EqualValues(t, 1, res.Parameters)
+	assert.EqualValues(t, 0, res.Functions)
+	assert.EqualValues(t, 1, res.Matrices)
+	assert.True(t, res.HasStreams)
+}
+
+func TestProbeWithoutKeepAliveReturnsSummary(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go func() {
+		buf := make([]byte, 1290)
+		// swallow the keep alive request without answering
+		_, err := provider.Read(buf)
+		if err != nil {
+			return
+		}
+		embertest.ServeMockProvider(provider, nil, rootDirectory())
+	}()
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := ec.Probe(ctx)
+	assert.Nil(t, err)
+	assert.False(t, res.KeepAliveSupported)
+	assert.EqualValues(t, 1, res.Matrices)
+}
+
+func TestProbeNotConnectedReturnsError(t *testing.T) {
+	ec, _ := NewEmberClient("127.0.0.1", 9000)
+	res, err := ec.Probe(context.Background())
+	assert.Nil(t, res)
+	assert.EqualValues(t, "not connected", err.Error())
+}
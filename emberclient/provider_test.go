@@ -0,0 +1,40 @@
+package emberclient
+
+// Access values of a qualified parameter.
+const (
+	accessRead      byte = 1
+	accessReadWrite byte = 3
+)
+
+// tlv encodes a definite length glow block.
+func tlv(tag byte, content ...[]byte) []byte {
+	var body []byte
+	for _, c := range content {
+		body = append(body, c...)
+	}
+
+	return append([]byte{tag, byte(len(body))}, body...)
+}
+
+// qualifiedIntParameter returns a glow root collection holding a single qualified integer parameter with the given
+// access.
+func qualifiedIntParameter(oid []byte, identifier string, value byte, access byte) []byte {
+	return glowRoot(qualifiedIntElement(oid, identifier, value, access))
+}
+
+// qualifiedIntElement returns a qualified integer parameter with the given access as element of a glow root.
+func qualifiedIntElement(oid []byte, identifier string, value byte, access byte) []byte {
+	contents := tlv(0xA1, tlv(0x31,
+		tlv(0xA0, tlv(0x0C, []byte(identifier))),
+		tlv(0xAD, tlv(0x02, []byte{0x01})),
+		tlv(0xA2, tlv(0x02, []byte{value})),
+		tlv(0xA5, tlv(0x02, []byte{access})),
+	))
+
+	return tlv(0xA0, tlv(0x69, tlv(0xA0, tlv(0x0D, oid)), contents))
+}
+
+// glowRoot returns a glow root collection holding elements.
+func glowRoot(elements ...[]byte) []byte {
+	return tlv(0x60, tlv(0x6B, elements...))
+}
@@ -0,0 +1,257 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Role identifies a provider of a redundant pair.
+type Role string
+
+const (
+	// RoleMain is the preferred provider of a redundant pair.
+	RoleMain Role = "main"
+	// RoleBackup takes over when the main provider fails.
+	RoleBackup Role = "backup"
+)
+
+// FailoverEvent is emitted when a redundant pair switches the active provider.
+type FailoverEvent struct {
+	From Role      `json:"from"`
+	To   Role      `json:"to"`
+	At   time.Time `json:"at"`
+	// Err is the error of the provider given up, it is nil when failing back to the healthy main provider.
+	Err error `json:"-"`
+}
+
+// PairHealth is the result of a health check of both providers of a redundant pair, a nil error means healthy.
+type PairHealth struct {
+	Main   error
+	Backup error
+	Active Role
+}
+
+// RedundantPair groups the main and backup provider of one logical device, as run by many broadcast cores. Reads and
+// writes go to the active provider, which is main whenever it is healthy, and fail over to the other provider on
+// connection errors. It is safe for concurrent use, requests and health checks are serialized per provider.
+type RedundantPair struct {
+	clients    map[Role]*EmberClient
+	clientMu   map[Role]*sync.Mutex
+	mu         sync.Mutex
+	active     Role
+	onFailover func(FailoverEvent)
+	clock      Clock
+}
+
+// NewRedundantPair creates a pair with main as active provider.
+func NewRedundantPair(main *EmberClient, backup *EmberClient) *RedundantPair {
+	return &RedundantPair{
+		clients:  map[Role]*EmberClient{RoleMain: main, RoleBackup: backup},
+		clientMu: map[Role]*sync.Mutex{RoleMain: {}, RoleBackup: {}},
+		active:   RoleMain,
+		clock:    systemClock{},
+	}
+}
+
+// OnFailover registers fn to be called, while the pair is locked, whenever the active provider changes.
+func (rp *RedundantPair) OnFailover(fn func(FailoverEvent)) {
+	rp.mu.Lock()
+	rp.onFailover = fn
+	rp.mu.Unlock()
+}
+
+// SetClock replaces the clock used for failover event times.
+func (rp *RedundantPair) SetClock(clock Clock) {
+	rp.mu.Lock()
+	rp.clock = clock
+	rp.mu.Unlock()
+}
+
+// Active returns the role of the provider requests are currently sent to.
+func (rp *RedundantPair) Active() Role {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	return rp.active
+}
+
+// CheckHealth connects disconnected providers and sends a keep alive request to both. The pair fails back to main if
+// it is healthy, or over to backup if main is not and backup is. Requests to the active provider are not blocked
+// while the other provider is checked.
+func (rp *RedundantPair) CheckHealth(ctx context.Context) PairHealth {
+	var health PairHealth
+
+	rp.use(RoleMain, func(ec *EmberClient) {
+		health.Main = checkClient(ctx, ec)
+	})
+	rp.use(RoleBackup, func(ec *EmberClient) {
+		health.Backup = checkClient(ctx, ec)
+	})
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	switch {
+	case health.Main == nil && rp.active != RoleMain:
+		rp.switchTo(RoleMain, nil)
+	case health.Main != nil && health.Backup == nil && rp.active != RoleBackup:
+		rp.switchTo(RoleBackup, health.Main)
+	}
+
+	health.Active = rp.active
+
+	return health
+}
+
+// GetValues works like EmberClient.GetValues on the active provider. Paths failing with connection errors are read
+// again from the other provider, which becomes active.
+func (rp *RedundantPair) GetValues(ctx context.Context, paths []string) (map[string]any, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	var (
+		values   map[string]any
+		err      error
+		pathErrs PathErrors
+		retry    []string
+	)
+
+	rp.use(rp.active, func(ec *EmberClient) {
+		values, err = ec.GetValues(ctx, paths)
+		if !errors.As(err, &pathErrs) {
+			return
+		}
+
+		for p, pathErr := range pathErrs {
+			if isConnectionError(ec, pathErr) {
+				retry = append(retry, p)
+			}
+		}
+	})
+
+	if len(retry) == 0 {
+		return values, err
+	}
+
+	rp.switchTo(rp.standby(), pathErrs[retry[0]])
+
+	var (
+		retried  map[string]any
+		retryErr error
+	)
+
+	rp.use(rp.active, func(ec *EmberClient) {
+		retried, retryErr = ec.GetValues(ctx, retry)
+	})
+
+	for p, v := range retried {
+		values[p] = v
+		delete(pathErrs, p)
+	}
+
+	var retryErrs PathErrors
+	if errors.As(retryErr, &retryErrs) {
+		for p, e := range retryErrs {
+			pathErrs[p] = e
+		}
+	}
+
+	if len(pathErrs) > 0 {
+		return values, pathErrs
+	}
+
+	return values, nil
+}
+
+// SetValue works like EmberClient.SetValue on the active provider. On connection errors the value is written to the
+// other provider, which becomes active.
+func (rp *RedundantPair) SetValue(ctx context.Context, path string, value any) (any, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	var (
+		confirmed any
+		err       error
+		failed    bool
+	)
+
+	rp.use(rp.active, func(ec *EmberClient) {
+		confirmed, err = ec.SetValue(ctx, path, value)
+		failed = err != nil && isConnectionError(ec, err)
+	})
+
+	if !failed {
+		return confirmed, err
+	}
+
+	rp.switchTo(rp.standby(), err)
+
+	rp.use(rp.active, func(ec *EmberClient) {
+		confirmed, err = ec.SetValue(ctx, path, value)
+	})
+
+	return confirmed, err
+}
+
+// use calls fn with the client of role while holding its lock, so requests and health checks do not interleave on a
+// connection.
+func (rp *RedundantPair) use(role Role, fn func(ec *EmberClient)) {
+	mu := rp.clientMu[role]
+	mu.Lock()
+	defer mu.Unlock()
+
+	fn(rp.clients[role])
+}
+
+// standby returns the role of the inactive provider.
+func (rp *RedundantPair) standby() Role {
+	if rp.active == RoleMain {
+		return RoleBackup
+	}
+
+	return RoleMain
+}
+
+// switchTo makes role active and emits a failover event.
+func (rp *RedundantPair) switchTo(role Role, cause error) {
+	event := FailoverEvent{From: rp.active, To: role, At: rp.clock.Now(), Err: cause}
+	rp.active = role
+
+	if rp.onFailover != nil {
+		rp.onFailover(event)
+	}
+}
+
+// isConnectionError returns true if err means the provider of ec is unreachable, as opposed to an error answer or a
+// malformed response.
+func isConnectionError(ec *EmberClient, err error) bool {
+	var netErr net.Error
+
+	return !ec.IsConnected() ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.As(err, &netErr)
+}
+
+// checkClient connects the client if needed and sends a keep alive request. Clients failing the check are
+// disconnected, so the next check connects again.
+func checkClient(ctx context.Context, ec *EmberClient) error {
+	if !ec.IsConnected() {
+		err := ec.Connect()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := ec.KeepAlive(ctx)
+	if err != nil {
+		ec.Disconnect()
+	}
+
+	return err
+}
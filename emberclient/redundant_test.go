@@ -0,0 +1,137 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/johannes-kuhfuss/emberplus/s101"
+	"github.com/stretchr/testify/assert"
+)
+
+// deadClient returns a client whose provider has already gone away.
+func deadClient() *EmberClient {
+	provider, consumer := net.Pipe()
+	provider.Close()
+
+	return NewEmberClientWithConn(consumer)
+}
+
+func TestRedundantPairGetValuesFailsOverToBackup(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, qualifiedIntParameter([]byte{1, 2}, "Gain", 5, accessRead))
+	rp := NewRedundantPair(deadClient(), NewEmberClientWithConn(consumer))
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	rp.SetClock(clock)
+	var events []FailoverEvent
+	rp.OnFailover(func(e FailoverEvent) { events = append(events, e) })
+
+	values, err := rp.GetValues(context.Background(), []string{"1.2"})
+	assert.Nil(t, err)
+	assert.EqualValues(t, map[string]any{"1.2": 5}, values)
+	assert.Equal(t, RoleBackup, rp.Active())
+	assert.Len(t, events, 1)
+	assert.Equal(t, RoleMain, events[0].From)
+	assert.Equal(t, RoleBackup, events[0].To)
+	assert.Equal(t, time.Unix(1700000000, 0), events[0].At)
+	assert.NotNil(t, events[0].Err)
+}
+
+func TestRedundantPairSetValueFailsOverToBackup(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, qualifiedIntParameter([]byte{1, 2}, "Gain", 7, accessReadWrite))
+	rp := NewRedundantPair(deadClient(), NewEmberClientWithConn(consumer))
+
+	value, err := rp.SetValue(context.Background(), "1.2", 7)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 7, value)
+	assert.Equal(t, RoleBackup, rp.Active())
+}
+
+func TestRedundantPairErrorAnswerDoesNotFailOver(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, qualifiedIntParameter([]byte{1, 3}, "Trim", 5, accessRead))
+	rp := NewRedundantPair(NewEmberClientWithConn(consumer), deadClient())
+
+	_, err := rp.GetValues(context.Background(), []string{"1.2"})
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.ErrorIs(t, pathErrs["1.2"], ErrElementNotFound)
+	assert.Equal(t, RoleMain, rp.Active())
+}
+
+func TestRedundantPairCheckHealthPrefersMain(t *testing.T) {
+	mainProvider, mainConsumer := net.Pipe()
+	defer mainProvider.Close()
+	backupProvider, backupConsumer := net.Pipe()
+	defer backupProvider.Close()
+	go embertest.ServeMockProvider(backupProvider, nil)
+	main := NewEmberClientWithConn(mainConsumer)
+	rp := NewRedundantPair(main, NewEmberClientWithConn(backupConsumer))
+	mainProvider.Close()
+
+	health := rp.CheckHealth(context.Background())
+	assert.NotNil(t, health.Main)
+	assert.Nil(t, health.Backup)
+	assert.Equal(t, RoleBackup, health.Active)
+	assert.False(t, main.IsConnected())
+
+	mainProvider, mainConsumer = net.Pipe()
+	defer mainProvider.Close()
+	go embertest.ServeMockProvider(mainProvider, nil)
+	go embertest.ServeMockProvider(backupProvider, nil)
+	rp.clients[RoleMain] = NewEmberClientWithConn(mainConsumer)
+
+	health = rp.CheckHealth(context.Background())
+	assert.Nil(t, health.Main)
+	assert.Equal(t, RoleMain, health.Active)
+}
+
+func TestRedundantPairProtocolErrorDoesNotFailOver(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go func() {
+		buf := make([]byte, 1290)
+		provider.Read(buf)
+		provider.Write(s101.Encode(qualifiedIntParameter([]byte{1, 2}, "Gain", 5, accessRead), s101.BodyMultiPacket))
+		provider.Write(s101.Encode(qualifiedIntParameter([]byte{1, 2}, "Gain", 5, accessRead), s101.SinglePacket))
+	}()
+	rp := NewRedundantPair(NewEmberClientWithConn(consumer), deadClient())
+
+	_, err := rp.GetValues(context.Background(), []string{"1.2"})
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.ErrorIs(t, pathErrs["1.2"], ErrProtocol)
+	assert.Equal(t, RoleMain, rp.Active())
+}
+
+func TestRedundantPairCheckHealthDoesNotBlockPair(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go io.Copy(io.Discard, provider)
+	rp := NewRedundantPair(NewEmberClientWithConn(consumer), deadClient())
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+
+	go func() {
+		rp.CheckHealth(ctx)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Equal(t, RoleMain, rp.Active())
+	select {
+	case <-done:
+		t.Fatal("health check returned before its keep alive timed out")
+	default:
+	}
+	<-done
+}
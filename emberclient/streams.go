@@ -0,0 +1,51 @@
+package emberclient
+
+import (
+	"github.com/johannes-kuhfuss/emberplus/ember"
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+// FilterStreams makes Receive skip stream collections sent by the device, so request/response users are not slowed
+// down by metering traffic. If streams is not nil, skipped collections are sent to it as glow data instead of being
+// dropped. Sending never blocks, collections are dropped while the channel is full.
+func (ec *EmberClient) FilterStreams(streams chan<- []byte) {
+	ec.filterStreams = true
+	ec.streams = streams
+}
+
+// skipStreams returns true if glow is a stream collection to be skipped by Receive, routing it if requested.
+func (ec *EmberClient) skipStreams(glow []byte) bool {
+	if !ec.filterStreams || ember.ClassifyMessage(glow) != ember.MessageStream {
+		return false
+	}
+
+	if ec.streams != nil {
+		select {
+		case ec.streams <- glow:
+		default:
+			ec.log().Debugf("dropping stream collection from %v, channel full", ec.raddr)
+		}
+	}
+
+	return true
+}
+
+// answerKeepAlives acknowledges keep alive requests of the device and returns the remaining packets.
+func (ec *EmberClient) answerKeepAlives(s101s [][]byte) [][]byte {
+	out := s101s[:0]
+
+	for _, p := range s101s {
+		if !s101.IsKeepAliveRequest(p) {
+			out = append(out, p)
+
+			continue
+		}
+
+		_, err := ec.Write(s101.EncodeKeepAliveResponse())
+		if err != nil {
+			ec.log().Errorf("failed to answer keep alive request of %v: %v", ec.raddr, err)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,83 @@
+package emberclient
+
+import (
+	"net"
+	"testing"
+
+	"github.com/johannes-kuhfuss/emberplus/s101"
+	"github.com/stretchr/testify/assert"
+)
+
+// meterStream is a glow stream collection with a single integer entry.
+func meterStream() []byte {
+	return tlv(0x60, tlv(0x66, tlv(0xA0, tlv(0x65, tlv(0xA0, tlv(0x02, []byte{0x01})), tlv(0xA1, tlv(0x02, []byte{0xEC}))))))
+}
+
+func TestReceiveWithoutFilterReturnsStreams(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go provider.Write(s101.Encode(meterStream(), s101.SinglePacket))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	glow, err := ec.Receive()
+	assert.Nil(t, err)
+	assert.EqualValues(t, meterStream(), glow)
+}
+
+func TestReceiveFilterStreamsRoutesStreams(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	answer := qualifiedIntParameter([]byte{1, 2, 1}, "Gain", 5, accessRead)
+	go func() {
+		provider.Write(s101.Encode(meterStream(), s101.SinglePacket))
+		provider.Write(s101.Encode(answer, s101.SinglePacket))
+	}()
+	streams := make(chan []byte, 1)
+	ec := NewEmberClientWithConn(consumer)
+	ec.FilterStreams(streams)
+	defer ec.Disconnect()
+
+	glow, err := ec.Receive()
+	assert.Nil(t, err)
+	assert.EqualValues(t, answer, glow)
+	assert.EqualValues(t, meterStream(), <-streams)
+}
+
+func TestReceiveFilterStreamsDropsStreams(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	answer := qualifiedIntParameter([]byte{1, 2, 1}, "Gain", 5, accessRead)
+	go func() {
+		provider.Write(s101.Encode(meterStream(), s101.SinglePacket))
+		provider.Write(s101.Encode(answer, s101.SinglePacket))
+	}()
+	ec := NewEmberClientWithConn(consumer)
+	ec.FilterStreams(nil)
+	defer ec.Disconnect()
+
+	glow, err := ec.Receive()
+	assert.Nil(t, err)
+	assert.EqualValues(t, answer, glow)
+}
+
+func TestReceiveKeepAliveRequestIsAnswered(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	answer := qualifiedIntParameter([]byte{1, 2, 1}, "Gain", 5, accessRead)
+	acked := make(chan bool, 1)
+	go func() {
+		provider.Write(s101.EncodeKeepAliveRequest())
+		buf := make([]byte, 1290)
+		n, err := provider.Read(buf)
+		acked <- err == nil && s101.IsKeepAliveResponse(buf[:n])
+		provider.Write(s101.Encode(answer, s101.SinglePacket))
+	}()
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	glow, err := ec.Receive()
+	assert.Nil(t, err)
+	assert.EqualValues(t, answer, glow)
+	assert.True(t, <-acked)
+}
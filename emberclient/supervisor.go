@@ -0,0 +1,244 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrBudgetExceeded is returned when starting a worker would exceed the goroutine budget of the supervisor.
+	ErrBudgetExceeded = errors.New("goroutine budget exceeded")
+	// ErrWorkerRunning is returned when a worker of the same name is still running.
+	ErrWorkerRunning = errors.New("worker already running")
+	// ErrSupervisorStopped is returned when starting a worker on a stopped supervisor.
+	ErrSupervisorStopped = errors.New("supervisor stopped")
+)
+
+// DefaultRestartDelay is the time a supervisor waits before restarting a worker that panicked.
+const DefaultRestartDelay = time.Second
+
+// WorkerState is the state of a supervised worker.
+type WorkerState string
+
+const (
+	// WorkerRunning worker is running.
+	WorkerRunning WorkerState = "running"
+	// WorkerRestarting worker panicked and waits to be restarted.
+	WorkerRestarting WorkerState = "restarting"
+	// WorkerStopped worker returned without error or was stopped by the supervisor, returning the context error.
+	WorkerStopped WorkerState = "stopped"
+	// WorkerFailed worker returned an error.
+	WorkerFailed WorkerState = "failed"
+)
+
+// WorkerStatus is the status of a supervised worker.
+type WorkerStatus struct {
+	Name     string      `json:"name"`
+	State    WorkerState `json:"state"`
+	Started  time.Time   `json:"started"`
+	Restarts int         `json:"restarts"`
+	// LastPanic is the value of the last recovered panic, empty if the worker never panicked.
+	LastPanic string `json:"last_panic,omitempty"`
+	// Err is the error a failed worker returned.
+	Err error `json:"-"`
+}
+
+// SupervisorStatus is a snapshot of a supervisor and its workers.
+type SupervisorStatus struct {
+	Running int            `json:"running"`
+	Budget  int            `json:"budget"`
+	Workers []WorkerStatus `json:"workers"`
+}
+
+// Supervisor starts the long-running loops of the library, e.g. Coalescer.Run, as named workers for processes
+// running for a long time. It limits the number of running workers, restarts workers that panic and reports the
+// state of every worker. It is safe for concurrent use.
+type Supervisor struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	budget       int
+	restartDelay time.Duration
+	logger       Logger
+	clock        Clock
+	mu           sync.Mutex
+	workers      map[string]*WorkerStatus
+	wg           sync.WaitGroup
+}
+
+// NewSupervisor creates a supervisor running at most budget workers at a time, 0 means unlimited.
+func NewSupervisor(budget int) *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Supervisor{
+		ctx:          ctx,
+		cancel:       cancel,
+		budget:       budget,
+		restartDelay: DefaultRestartDelay,
+		clock:        systemClock{},
+		workers:      make(map[string]*WorkerStatus),
+	}
+}
+
+// SetRestartDelay replaces the time waited before restarting a worker that panicked.
+func (s *Supervisor) SetRestartDelay(d time.Duration) {
+	s.mu.Lock()
+	s.restartDelay = d
+	s.mu.Unlock()
+}
+
+// SetLogger replaces the logger worker panics and failures are logged to, nil restores the default logger.
+func (s *Supervisor) SetLogger(l Logger) {
+	s.mu.Lock()
+	s.logger = l
+	s.mu.Unlock()
+}
+
+// SetClock replaces the clock used for worker start times and restart delays.
+func (s *Supervisor) SetClock(clock Clock) {
+	s.mu.Lock()
+	s.clock = clock
+	s.mu.Unlock()
+}
+
+// Go starts fn as worker called name. The context passed to fn is cancelled by Stop. If fn panics it is called again
+// after the restart delay, if it returns the worker ends. The name of a worker that ended can be used again.
+func (s *Supervisor) Go(name string, fn func(ctx context.Context) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return ErrSupervisorStopped
+	}
+
+	if w, ok := s.workers[name]; ok && (w.State == WorkerRunning || w.State == WorkerRestarting) {
+		return fmt.Errorf("%w: %s", ErrWorkerRunning, name)
+	}
+
+	if s.budget > 0 && s.running() >= s.budget {
+		return fmt.Errorf("%w: %d workers running", ErrBudgetExceeded, s.budget)
+	}
+
+	w := &WorkerStatus{Name: name, State: WorkerRunning, Started: s.clock.Now()}
+	s.workers[name] = w
+
+	s.wg.Add(1)
+
+	go s.run(w, fn)
+
+	return nil
+}
+
+// Status returns a snapshot of all workers in name order, including those that ended.
+func (s *Supervisor) Status() SupervisorStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := SupervisorStatus{Running: s.running(), Budget: s.budget, Workers: make([]WorkerStatus, 0, len(s.workers))}
+
+	for _, w := range s.workers {
+		status.Workers = append(status.Workers, *w)
+	}
+
+	sort.Slice(status.Workers, func(i, j int) bool {
+		return status.Workers[i].Name < status.Workers[j].Name
+	})
+
+	return status
+}
+
+// Stop cancels the context of all workers and waits until they returned. No workers can be started afterwards.
+func (s *Supervisor) Stop() {
+	// Cancelling under the lock orders Stop after any Go adding to the wait group, Go afterwards sees the cancelled
+	// context and never adds while Wait runs.
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// run calls fn until it returns without panic or the supervisor is stopped.
+func (s *Supervisor) run(w *WorkerStatus, fn func(ctx context.Context) error) {
+	defer s.wg.Done()
+
+	for {
+		recovered, err := s.call(fn)
+
+		s.mu.Lock()
+
+		if recovered == nil {
+			if errors.Is(err, context.Canceled) && s.ctx.Err() != nil {
+				err = nil
+			}
+
+			w.State, w.Err = WorkerStopped, err
+			if err != nil {
+				w.State = WorkerFailed
+				s.log().Errorf("worker %s failed: %v", w.Name, err)
+			}
+
+			s.mu.Unlock()
+
+			return
+		}
+
+		w.State = WorkerRestarting
+		w.Restarts++
+		w.LastPanic = fmt.Sprint(recovered)
+		delay := s.restartDelay
+		clock := s.clock
+		s.log().Errorf("worker %s panicked, restarting in %v: %v", w.Name, delay, recovered)
+
+		s.mu.Unlock()
+
+		select {
+		case <-s.ctx.Done():
+			s.mu.Lock()
+			w.State = WorkerStopped
+			s.mu.Unlock()
+
+			return
+		case <-clock.After(delay):
+		}
+
+		s.mu.Lock()
+		w.State = WorkerRunning
+		w.Started = s.clock.Now()
+		s.mu.Unlock()
+	}
+}
+
+// call calls fn once and returns the recovered panic value, if fn panicked.
+func (s *Supervisor) call(fn func(ctx context.Context) error) (recovered any, err error) {
+	defer func() {
+		recovered = recover()
+	}()
+
+	return nil, fn(s.ctx)
+}
+
+// running returns the number of workers running or waiting to be restarted, the caller must hold the lock.
+func (s *Supervisor) running() int {
+	n := 0
+
+	for _, w := range s.workers {
+		if w.State == WorkerRunning || w.State == WorkerRestarting {
+			n++
+		}
+	}
+
+	return n
+}
+
+// log returns the logger of the supervisor, the caller must hold the lock.
+func (s *Supervisor) log() Logger {
+	if s.logger == nil {
+		return defaultLogger
+	}
+
+	return s.logger
+}
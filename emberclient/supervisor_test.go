@@ -0,0 +1,177 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockUntilDone is a worker running until the supervisor is stopped.
+func blockUntilDone(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func TestSupervisorStatusListsWorkers(t *testing.T) {
+	s := NewSupervisor(0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.SetClock(NewManualClock(start))
+
+	assert.Nil(t, s.Go("reader", blockUntilDone))
+	assert.Nil(t, s.Go("housekeeper", blockUntilDone))
+
+	status := s.Status()
+	assert.Equal(t, 2, status.Running)
+	assert.Equal(t, []WorkerStatus{
+		{Name: "housekeeper", State: WorkerRunning, Started: start},
+		{Name: "reader", State: WorkerRunning, Started: start},
+	}, status.Workers)
+
+	s.Stop()
+
+	status = s.Status()
+	assert.Equal(t, 0, status.Running)
+	assert.Equal(t, WorkerStopped, status.Workers[0].State)
+	assert.Nil(t, status.Workers[0].Err)
+}
+
+func TestSupervisorGoReturnsErrBudgetExceeded(t *testing.T) {
+	s := NewSupervisor(1)
+	defer s.Stop()
+
+	assert.Nil(t, s.Go("reader", blockUntilDone))
+
+	err := s.Go("watcher", blockUntilDone)
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	assert.Equal(t, 1, s.Status().Budget)
+}
+
+func TestSupervisorGoReturnsErrWorkerRunning(t *testing.T) {
+	s := NewSupervisor(0)
+	defer s.Stop()
+
+	assert.Nil(t, s.Go("reader", blockUntilDone))
+	assert.ErrorIs(t, s.Go("reader", blockUntilDone), ErrWorkerRunning)
+}
+
+func TestSupervisorGoReturnsErrSupervisorStopped(t *testing.T) {
+	s := NewSupervisor(0)
+	s.Stop()
+
+	assert.ErrorIs(t, s.Go("reader", blockUntilDone), ErrSupervisorStopped)
+}
+
+func TestSupervisorStopWhileStartingWorkers(t *testing.T) {
+	s := NewSupervisor(0)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; ; i++ {
+			if err := s.Go(fmt.Sprintf("worker%d", i), blockUntilDone); err != nil {
+				assert.ErrorIs(t, err, ErrSupervisorStopped)
+
+				return
+			}
+		}
+	}()
+
+	s.Stop()
+	<-done
+
+	assert.Equal(t, 0, s.Status().Running)
+}
+
+func TestSupervisorRestartsPanickingWorker(t *testing.T) {
+	s := NewSupervisor(0)
+	s.SetRestartDelay(0)
+	l := &recordingLogger{}
+	s.SetLogger(l)
+	restarted := make(chan struct{})
+	calls := 0
+
+	assert.Nil(t, s.Go("watcher", func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+
+		close(restarted)
+
+		return blockUntilDone(ctx)
+	}))
+
+	<-restarted
+
+	status := s.Status().Workers[0]
+	assert.Equal(t, WorkerRunning, status.State)
+	assert.Equal(t, 1, status.Restarts)
+	assert.Equal(t, "boom", status.LastPanic)
+
+	s.Stop()
+	assert.Equal(t, []string{"worker watcher panicked, restarting in 0s: boom"}, l.errors)
+}
+
+func TestSupervisorRestartWaitsForDelayOnClock(t *testing.T) {
+	s := NewSupervisor(0)
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	s.SetClock(clock)
+	s.SetRestartDelay(time.Minute)
+	s.SetLogger(&recordingLogger{})
+	restarted := make(chan struct{})
+	calls := 0
+
+	assert.Nil(t, s.Go("watcher", func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+
+		close(restarted)
+
+		return blockUntilDone(ctx)
+	}))
+
+	waitForTimers(t, clock, 1)
+	assert.Equal(t, WorkerRestarting, s.Status().Workers[0].State)
+	clock.Advance(time.Minute)
+	<-restarted
+
+	status := s.Status().Workers[0]
+	assert.Equal(t, WorkerRunning, status.State)
+	assert.Equal(t, time.Unix(1700000060, 0), status.Started)
+	s.Stop()
+}
+
+func TestSupervisorReportsFailedWorker(t *testing.T) {
+	s := NewSupervisor(1)
+	s.SetLogger(&recordingLogger{})
+	failure := errors.New("connection lost")
+
+	assert.Nil(t, s.Go("reader", func(context.Context) error { return failure }))
+	s.Stop()
+
+	status := s.Status()
+	assert.Equal(t, WorkerFailed, status.Workers[0].State)
+	assert.ErrorIs(t, status.Workers[0].Err, failure)
+}
+
+func TestSupervisorRunsCoalescer(t *testing.T) {
+	s := NewSupervisor(0)
+	c := NewCoalescer(deadClient(), time.Hour)
+
+	assert.Nil(t, s.Go("coalescer", func(ctx context.Context) error {
+		c.Run(ctx, nil)
+
+		return nil
+	}))
+	s.Stop()
+
+	assert.Equal(t, WorkerStopped, s.Status().Workers[0].State)
+}
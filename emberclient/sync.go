@@ -0,0 +1,205 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+// defaultSetValueTimeout limits how long SetValue waits for the confirmation if the context has no deadline, as some
+// providers do not echo unchanged values.
+const defaultSetValueTimeout = 5 * time.Second
+
+// SyncOptions controls which parameters Sync copies and whether they are written at all.
+type SyncOptions struct {
+	// DryRun only reports the differing values, nothing is written to the target device.
+	DryRun bool
+	// Include limits the sync to the listed paths and their sub trees, all paths are synced if empty.
+	Include []string
+	// Exclude skips the listed paths and their sub trees, it takes precedence over Include.
+	Exclude []string
+}
+
+// SetValue sets the value of the parameter at path and returns the value confirmed by the provider, which may differ
+// from the requested one, e.g. if the provider clamps it to the parameter range. Updates of other elements arriving
+// before the confirmation are skipped, as are messages which can not be decoded, e.g. streams. The context deadline,
+// or a default timeout if it has none, is applied to the connection and cancelling the context interrupts waiting for
+// the confirmation.
+func (ec *EmberClient) SetValue(ctx context.Context, path string, value any) (any, error) {
+	if !ec.IsConnected() {
+		return nil, errors.New("not connected")
+	}
+
+	m, err := ember.CompilePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ember.GetSetValueRequest(path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, defaultSetValueTimeout)
+		defer cancel()
+	}
+
+	defer ec.watchContext(ctx)()
+
+	if ec.pendingWrites != nil {
+		ec.pendingWrites.Track(m.String(), value)
+	}
+
+	_, err = ec.Write(req)
+	if err != nil {
+		if ec.pendingWrites != nil {
+			ec.pendingWrites.fail(m.String())
+		}
+
+		return nil, fmt.Errorf("failed to write set value request: %w", err)
+	}
+
+	for {
+		out, err := ec.Receive()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+
+			return nil, err
+		}
+
+		coll := ember.NewElementCollection()
+
+		err = coll.Populate(asn1.NewDecoder(out))
+		if err != nil {
+			ec.log().Debugf("skipping message while waiting for confirmation of %v: %v", path, classifyGlowError(out, err))
+
+			continue
+		}
+
+		if ec.pendingWrites != nil {
+			ec.pendingWrites.Observe(coll)
+		}
+
+		el, err := coll.GetElementByMatcher(m)
+		if err != nil {
+			continue
+		}
+
+		return el.Value, nil
+	}
+}
+
+// Sync reads the parameters at paths from source and writes all differing values to target, which must be a device
+// of the same model, e.g. spare hardware replacing source. Paths must name parameters, nodes are not walked. Only
+// parameters writable on target and selected by the options are written, including parameters the devices return
+// beyond the requested ones. The changes are returned in path order, if some paths could not be read or written they are returned together with a
+// PathErrors error describing every failed path.
+func Sync(ctx context.Context, source *EmberClient, target *EmberClient, paths []string, opts SyncOptions) ([]ember.ValueChange, error) {
+	pathErrs := make(PathErrors)
+	sourceColl := ember.NewElementCollection()
+	targetColl := ember.NewElementCollection()
+
+	for _, path := range paths {
+		if !opts.selects(path) {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			pathErrs[path] = err
+
+			continue
+		}
+
+		err := readInto(source, sourceColl, path)
+		if err != nil {
+			pathErrs[path] = fmt.Errorf("failed to read source: %w", err)
+
+			continue
+		}
+
+		err = readInto(target, targetColl, path)
+		if err != nil {
+			pathErrs[path] = fmt.Errorf("failed to read target: %w", err)
+		}
+	}
+
+	var changes []ember.ValueChange
+
+	for _, change := range ember.DiffValues(sourceColl, targetColl) {
+		if opts.selects(change.Path) {
+			changes = append(changes, change)
+		}
+	}
+
+	if !opts.DryRun {
+		for _, change := range changes {
+			if err := ctx.Err(); err != nil {
+				pathErrs[change.Path] = err
+
+				continue
+			}
+
+			_, err := target.SetValue(ctx, change.Path, change.New)
+			if err != nil {
+				pathErrs[change.Path] = fmt.Errorf("failed to write target: %w", err)
+			}
+		}
+	}
+
+	if len(pathErrs) > 0 {
+		return changes, pathErrs
+	}
+
+	return changes, nil
+}
+
+// readInto requests the parameter at path and adds the returned elements to coll. The path is requested as parameter,
+// so a node path does not return the parameters below it.
+func readInto(ec *EmberClient, coll ember.ElementCollection, path string) error {
+	read, err := ec.getCollection(asn1.QualifiedParameterType, path)
+	if err != nil {
+		return err
+	}
+
+	for key, el := range read {
+		coll[key] = el
+	}
+
+	return nil
+}
+
+// selects returns true if path is not excluded and either included or no includes are configured.
+func (opts SyncOptions) selects(path string) bool {
+	for _, ex := range opts.Exclude {
+		if inSubtree(path, ex) {
+			return false
+		}
+	}
+
+	if len(opts.Include) == 0 {
+		return true
+	}
+
+	for _, in := range opts.Include {
+		if inSubtree(path, in) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inSubtree returns true if path equals root or is a descendant of it.
+func inSubtree(path string, root string) bool {
+	return path == root || strings.HasPrefix(path, root+".")
+}
@@ -0,0 +1,139 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/ember"
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/johannes-kuhfuss/emberplus/s101"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetValueReturnsConfirmedValue(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil, qualifiedIntParameter([]byte{1, 2}, "Gain", 6, accessReadWrite))
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	value, err := ec.SetValue(context.Background(), "1.2", 7)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 6, value)
+}
+
+func TestSetValueSkipsUnrelatedUpdates(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go func() {
+		buf := make([]byte, 1290)
+		provider.Read(buf)
+		provider.Write(s101.Encode(qualifiedIntParameter([]byte{1, 3}, "Mute", 1, accessReadWrite), s101.SinglePacket))
+		provider.Write(s101.Encode([]byte{0x66, 0x03, 0x01, 0x02, 0x03}, s101.SinglePacket))
+		provider.Write(s101.Encode(qualifiedIntParameter([]byte{1, 2}, "Gain", 7, accessReadWrite), s101.SinglePacket))
+	}()
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	value, err := ec.SetValue(context.Background(), "1.2", 7)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 7, value)
+}
+
+func TestSetValueCancelInterruptsConfirmation(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go io.Copy(io.Discard, provider)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err := ec.SetValue(ctx, "1.2", 7)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSyncWritesDifferingValues(t *testing.T) {
+	srcProvider, srcConsumer := net.Pipe()
+	defer srcProvider.Close()
+	go embertest.ServeMockProvider(srcProvider, nil,
+		qualifiedIntParameter([]byte{1, 1}, "Gain", 5, accessReadWrite),
+		qualifiedIntParameter([]byte{1, 2}, "Trim", 7, accessReadWrite),
+	)
+	dstProvider, dstConsumer := net.Pipe()
+	defer dstProvider.Close()
+	requests := make(chan []byte, 3)
+	go embertest.ServeMockProvider(dstProvider, requests,
+		qualifiedIntParameter([]byte{1, 1}, "Gain", 5, accessReadWrite),
+		qualifiedIntParameter([]byte{1, 2}, "Trim", 1, accessReadWrite),
+		qualifiedIntParameter([]byte{1, 2}, "Trim", 7, accessReadWrite),
+	)
+	source := NewEmberClientWithConn(srcConsumer)
+	defer source.Disconnect()
+	target := NewEmberClientWithConn(dstConsumer)
+	defer target.Disconnect()
+
+	changes, err := Sync(context.Background(), source, target, []string{"1.1", "1.2"}, SyncOptions{})
+	assert.Nil(t, err)
+	assert.EqualValues(t, []ember.ValueChange{{Path: "1.2", Identifier: "Trim", Old: 1, New: 7}}, changes)
+	req, _ := ember.GetSetValueRequest("1.2", 7)
+	<-requests
+	<-requests
+	assert.EqualValues(t, req, <-requests)
+}
+
+func TestSyncDryRunAndFiltersDoNotWrite(t *testing.T) {
+	srcProvider, srcConsumer := net.Pipe()
+	defer srcProvider.Close()
+	go embertest.ServeMockProvider(srcProvider, nil, qualifiedIntParameter([]byte{1, 2}, "Trim", 7, accessReadWrite))
+	dstProvider, dstConsumer := net.Pipe()
+	defer dstProvider.Close()
+	go embertest.ServeMockProvider(dstProvider, nil, qualifiedIntParameter([]byte{1, 2}, "Trim", 1, accessReadWrite))
+	source := NewEmberClientWithConn(srcConsumer)
+	defer source.Disconnect()
+	target := NewEmberClientWithConn(dstConsumer)
+	defer target.Disconnect()
+
+	opts := SyncOptions{DryRun: true, Include: []string{"1"}, Exclude: []string{"1.1"}}
+	changes, err := Sync(context.Background(), source, target, []string{"1.1.4", "1.2", "2.1"}, opts)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []ember.ValueChange{{Path: "1.2", Identifier: "Trim", Old: 1, New: 7}}, changes)
+}
+
+func TestSyncSkipsExcludedReturnedParameters(t *testing.T) {
+	srcProvider, srcConsumer := net.Pipe()
+	defer srcProvider.Close()
+	go embertest.ServeMockProvider(srcProvider, nil, glowRoot(
+		qualifiedIntElement([]byte{1, 2}, "Trim", 7, accessReadWrite),
+		qualifiedIntElement([]byte{1, 3}, "Mute", 1, accessReadWrite),
+	))
+	dstProvider, dstConsumer := net.Pipe()
+	defer dstProvider.Close()
+	go embertest.ServeMockProvider(dstProvider, nil, glowRoot(
+		qualifiedIntElement([]byte{1, 2}, "Trim", 7, accessReadWrite),
+		qualifiedIntElement([]byte{1, 3}, "Mute", 0, accessReadWrite),
+	))
+	source := NewEmberClientWithConn(srcConsumer)
+	defer source.Disconnect()
+	target := NewEmberClientWithConn(dstConsumer)
+	defer target.Disconnect()
+
+	changes, err := Sync(context.Background(), source, target, []string{"1.2"}, SyncOptions{Exclude: []string{"1.3"}})
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestSyncCancelledContextReturnsPathErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	changes, err := Sync(ctx, &EmberClient{}, &EmberClient{}, []string{"1.2"}, SyncOptions{})
+	assert.Empty(t, changes)
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.ErrorIs(t, pathErrs["1.2"], context.Canceled)
+}
@@ -0,0 +1,22 @@
+// Serves a small tree on port 9000. The integer parameter 1.1 is writable, so it can be used as EMBER_SET_PATH.
+const { EmberServer, Model } = require('emberplus-connection')
+
+const gain = new Model.NumberedTreeNodeImpl(
+	1,
+	new Model.ParameterImpl(Model.ParameterType.Integer, 'gain', 'Channel gain', 0, 64, -64, Model.ParameterAccess.ReadWrite)
+)
+
+const root = {
+	1: new Model.NumberedTreeNodeImpl(1, new Model.EmberNodeImpl('mixer', 'Integration test mixer', undefined, true), {
+		1: gain,
+	}),
+}
+
+const server = new EmberServer(9000)
+server.onSetValue = async (node, value) => {
+	server.update(node, { value })
+
+	return true
+}
+server.on('error', (err) => console.error(err))
+server.init(root)
@@ -0,0 +1,117 @@
+package emberclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/asn1"
+	"github.com/johannes-kuhfuss/emberplus/ember"
+)
+
+// PathErrors holds the errors of the paths that could not be read by GetValues, keyed by the path as requested.
+type PathErrors map[string]error
+
+// Error lists all failed paths with their error, in numeric path order.
+func (pe PathErrors) Error() string {
+	paths := make([]string, 0, len(pe))
+	for p := range pe {
+		paths = append(paths, p)
+	}
+
+	ember.SortPaths(paths)
+
+	msgs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", p, pe[p]))
+	}
+
+	return fmt.Sprintf("failed to get %d value(s): %s", len(pe), strings.Join(msgs, "; "))
+}
+
+// GetValues reads the values of many parameters over the existing connection, one request per path, and returns
+// them keyed by the path as requested. If some paths fail, the values read so far are returned together with a
+// PathErrors error describing every failed path. The context deadline is applied to the connection and cancelling
+// the context interrupts the pending request and fails all paths not yet read.
+func (ec *EmberClient) GetValues(ctx context.Context, paths []string) (map[string]any, error) {
+	values := make(map[string]any, len(paths))
+	pathErrs := make(PathErrors)
+
+	defer ec.watchContext(ctx)()
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			pathErrs[path] = err
+
+			continue
+		}
+
+		value, err := ec.getValue(path)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+
+			pathErrs[path] = err
+
+			continue
+		}
+
+		values[path] = value
+	}
+
+	if len(pathErrs) > 0 {
+		return values, pathErrs
+	}
+
+	return values, nil
+}
+
+// getValue requests a single parameter and returns its value.
+func (ec *EmberClient) getValue(path string) (any, error) {
+	m, err := ember.CompilePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := ec.getCollection(asn1.QualifiedParameterType, path)
+	if err != nil {
+		return nil, err
+	}
+
+	el, err := coll.GetElementByMatcher(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Value, nil
+}
+
+// watchContext applies the context deadline to the connection and interrupts blocked reads and writes once the context
+// is cancelled. The returned function restores the connection and must be called when the request is done.
+func (ec *EmberClient) watchContext(ctx context.Context) func() {
+	if !ec.IsConnected() {
+		return func() {}
+	}
+
+	conn := ec.conn
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Unix(1, 0))
+		close(interrupted)
+	})
+
+	return func() {
+		if !stop() {
+			<-interrupted
+		}
+
+		conn.SetDeadline(time.Time{})
+	}
+}
@@ -0,0 +1,85 @@
+package emberclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/johannes-kuhfuss/emberplus/emberclient/embertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValuesAllPathsReturnsValues(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil,
+		qualifiedIntParameter([]byte{1, 2, 1}, "Gain", 5, accessRead),
+		qualifiedIntParameter([]byte{1, 2, 2}, "Trim", 7, accessRead),
+	)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	values, err := ec.GetValues(context.Background(), []string{"1.2.1", "1/2/2"})
+	assert.Nil(t, err)
+	assert.EqualValues(t, map[string]any{"1.2.1": 5, "1/2/2": 7}, values)
+}
+
+func TestGetValuesPartialFailureReturnsPathErrors(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go embertest.ServeMockProvider(provider, nil,
+		qualifiedIntParameter([]byte{1, 2, 1}, "Gain", 5, accessRead),
+		qualifiedIntParameter([]byte{1, 2, 9}, "Other", 1, accessRead),
+	)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+
+	values, err := ec.GetValues(context.Background(), []string{"1.2.1", "1.x", "1.2.2"})
+	assert.EqualValues(t, map[string]any{"1.2.1": 5}, values)
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.EqualValues(t, 2, len(pathErrs))
+	assert.Contains(t, pathErrs["1.x"].Error(), "not a number")
+	assert.Contains(t, pathErrs["1.2.2"].Error(), "element not found")
+}
+
+func TestGetValuesCancelledContextReturnsPathErrors(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	values, err := ec.GetValues(ctx, []string{"1.2.1"})
+	assert.EqualValues(t, 0, len(values))
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.True(t, errors.Is(pathErrs["1.2.1"], context.Canceled))
+}
+
+func TestGetValuesCancelInterruptsBlockedRead(t *testing.T) {
+	provider, consumer := net.Pipe()
+	defer provider.Close()
+	go io.Copy(io.Discard, provider)
+	ec := NewEmberClientWithConn(consumer)
+	defer ec.Disconnect()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	values, err := ec.GetValues(ctx, []string{"1.2.1", "1.2.2"})
+	assert.Empty(t, values)
+	var pathErrs PathErrors
+	assert.True(t, errors.As(err, &pathErrs))
+	assert.ErrorIs(t, pathErrs["1.2.1"], context.Canceled)
+	assert.ErrorIs(t, pathErrs["1.2.2"], context.Canceled)
+}
+
+func TestPathErrorsErrorListsPathsInNumericOrder(t *testing.T) {
+	pathErrs := PathErrors{"1.10": ErrElementNotFound, "1.2": ErrElementNotFound}
+
+	assert.Equal(t, "failed to get 2 value(s): 1.2: element not found; 1.10: element not found", pathErrs.Error())
+}
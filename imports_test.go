@@ -0,0 +1,73 @@
+package emberplus
+
+import (
+	"go/build"
+	"testing"
+)
+
+// corePackages are the codec packages, which must stay free of network and process dependencies, so they can be used
+// in WASM based tools and embedded controllers providing their own transport.
+//
+//nolint:gochecknoglobals
+var corePackages = []string{
+	modulePath + "/asn1",
+	modulePath + "/s101",
+	modulePath + "/ember",
+}
+
+// forbiddenImports must not be imported by core packages, neither directly nor through their dependencies.
+//
+//nolint:gochecknoglobals
+var forbiddenImports = map[string]bool{
+	"net":        true,
+	"net/http":   true,
+	"net/url":    true,
+	"os/exec":    true,
+	"crypto/tls": true,
+	"github.com/johannes-kuhfuss/services_utils/logger": true,
+}
+
+func TestCorePackagesHaveNoTransportImports(t *testing.T) {
+	t.Parallel()
+
+	for _, pkg := range corePackages {
+		pkg := pkg
+		t.Run(pkg, func(t *testing.T) {
+			t.Parallel()
+
+			seen := make(map[string]bool)
+
+			if chain := forbiddenChain(t, pkg, seen); chain != nil {
+				t.Fatalf("%s imports %v", pkg, chain)
+			}
+		})
+	}
+}
+
+// forbiddenChain returns the import chain from pkg to a forbidden import, or nil if there is none.
+func forbiddenChain(t *testing.T, pkg string, seen map[string]bool) []string {
+	t.Helper()
+
+	if forbiddenImports[pkg] {
+		return []string{pkg}
+	}
+
+	if seen[pkg] || pkg == "C" || pkg == "unsafe" {
+		return nil
+	}
+
+	seen[pkg] = true
+
+	p, err := build.Import(pkg, ".", 0)
+	if err != nil {
+		t.Fatalf("failed to import %s: %v", pkg, err)
+	}
+
+	for _, imp := range p.Imports {
+		if chain := forbiddenChain(t, imp, seen); chain != nil {
+			return append([]string{pkg}, chain...)
+		}
+	}
+
+	return nil
+}
@@ -31,6 +31,26 @@ func Encode(message []byte, packetType uint8) []uint8 {
 	return out
 }
 
+// EncodeKeepAliveRequest creates a S101 keep alive request, which providers answer with a keep alive response.
+func EncodeKeepAliveRequest() []byte {
+	return createKeepAlive(keepAliveRequest)
+}
+
+// EncodeKeepAliveResponse creates a S101 keep alive response, used to answer keep alive requests of a provider.
+func EncodeKeepAliveResponse() []byte {
+	return createKeepAlive(keepAliveResponse)
+}
+
+// IsKeepAliveRequest returns true if the S101 packet, as returned by GetS101s, is a keep alive request.
+func IsKeepAliveRequest(s101 []byte) bool {
+	return isKeepAlive(s101, keepAliveRequest)
+}
+
+// IsKeepAliveResponse returns true if the S101 packet, as returned by GetS101s, is a keep alive response.
+func IsKeepAliveResponse(s101 []byte) bool {
+	return isKeepAlive(s101, keepAliveResponse)
+}
+
 // GetS101s returns all s101 data packets from message, if the message contains an incomplete packet it will return the
 // raw data in the second response value.
 func GetS101s(message []byte) ([][]byte, []byte, error) {
@@ -59,8 +79,8 @@ func Decode(s101s [][]byte) ([]byte, byte, error) {
 			lastPacketType = s101[5]
 		}
 
-		// remove checksum and end of frame byte, this check is done here as not to XOR a checksum byte
-		s101 = s101[:len(s101)-s101LenAfterGlow]
+		// remove end of frame byte, the checksum is removed after unescaping as its bytes may be escaped as well
+		s101 = s101[:len(s101)-1]
 
 		var (
 			ceFound bool
@@ -85,12 +105,28 @@ func Decode(s101s [][]byte) ([]byte, byte, error) {
 			glow = append(glow, b)
 		}
 
-		out = append(out, glow[s101LenTilGlow:]...)
+		if len(glow) < s101LenTilGlow+crcLen {
+			return nil, 0, fmt.Errorf("malformed s101 packet, header shorter than expected: %x", s101)
+		}
+
+		out = append(out, glow[s101LenTilGlow:len(glow)-crcLen]...)
 	}
 
 	return out, lastPacketType, nil
 }
 
+// SafeDecode works like Decode, but recovers from any panic raised while decoding malformed data and returns it as
+// an error wrapping ErrDecodePanic instead.
+func SafeDecode(s101s [][]byte) (glow []byte, lastPacketType byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			glow, lastPacketType, err = nil, 0, fmt.Errorf("%w: %v", ErrDecodePanic, r)
+		}
+	}()
+
+	return Decode(s101s)
+}
+
 // getS101s reads the last entry in the byte array start starts with BOF byte and ends with EOF byte.
 // if data is incomplete, returns it as second parameter.
 func getS101s(in []uint8) ([][]uint8, []uint8) {
@@ -146,7 +182,7 @@ func getS101s(in []uint8) ([][]uint8, []uint8) {
 // createS101 creates a S101 packet from the provided payload and packet type.
 func createS101(payload []byte, pType uint8) []byte {
 	escaped := escapeBytesAboveBOFNE(payload)
-	s101Info := []byte{slot, messageType, commandType, version, pType, dtdType, appBytes, minorVersion, majorVersion}
+	s101Info := []byte{slot, messageType, commandType, Version, pType, dtdType, appBytes, GlowMinorVersion, GlowMajorVersion}
 	tmp := make([]byte, 0, len(s101Info)+len(payload))
 
 	tmp = append(tmp, s101Info...)
@@ -162,6 +198,29 @@ func createS101(payload []byte, pType uint8) []byte {
 	return s101
 }
 
+// createKeepAlive creates a S101 keep alive packet with the provided command, keep alive packets have no payload.
+func createKeepAlive(command uint8) []byte {
+	s101Info := []byte{slot, messageType, command, Version}
+	crc := getCRC(s101Info)
+
+	s101 := make([]byte, 0, len(s101Info)+len(crc)+2)
+	s101 = append(s101, bof)
+	s101 = append(s101, s101Info...)
+	s101 = append(s101, crc...)
+	s101 = append(s101, eof)
+
+	return s101
+}
+
+// isKeepAlive checks if the S101 packet is a keep alive packet with the provided command.
+func isKeepAlive(s101 []byte, command uint8) bool {
+	if len(s101) < keepAliveLen || s101[0] != bof {
+		return false
+	}
+
+	return s101[2] == messageType && s101[3] == command
+}
+
 // escapeBytesAboveBOFNE parses the message as based on Glow protocol all the bytes with bigger value then 0xf8 must
 // preceded with and 0xfd byte and XORed with 0x20 byte.
 func escapeBytesAboveBOFNE(message []byte) []byte {
@@ -115,6 +115,23 @@ func TestDecode(t *testing.T) {
 			SinglePacket,
 			false,
 		},
+		{
+			"+escapedCRC",
+			args{
+				[][]byte{
+					{
+						0xfe, 0x00, 0x0e, 0x00, 0x01, 0xc0, 0x01, 0x02, 0x28, 0x02, 0x60, 0x10, 0x66, 0x0e, 0xa0, 0x0c,
+						0x65, 0x0a, 0xa0, 0x03, 0x02, 0x01, 0x01, 0xa1, 0x03, 0x02, 0x01, 0xec, 0xbd, 0xfd, 0xda, 0xff,
+					},
+				},
+			},
+			[]byte{
+				0x60, 0x10, 0x66, 0x0e, 0xa0, 0x0c, 0x65, 0x0a, 0xa0, 0x03, 0x02, 0x01, 0x01, 0xa1, 0x03, 0x02, 0x01,
+				0xec,
+			},
+			SinglePacket,
+			false,
+		},
 		{
 			"+functionRequest",
 			args{
@@ -306,6 +323,13 @@ func TestDecode(t *testing.T) {
 			0,
 			true,
 		},
+		{
+			"-escapedShortHeader",
+			args{[][]byte{{0xfe, 0xfd, 0xde, 0xfd, 0xde, 0xfd, 0xde, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff}}},
+			nil,
+			0,
+			true,
+		},
 		{
 			"-empty",
 			args{[][]byte{}},
@@ -335,6 +359,52 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestSafeDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		message  [][]byte
+		wantData []byte
+		wantType byte
+		wantErr  bool
+	}{
+		{
+			"+valid",
+			[][]byte{{0xfe, 0x00, 0x0e, 0x00, 0x01, 0xc0, 0x01, 0x02, 0x28, 0x02, 0x60, 0x80, 0x00, 0x00, 0xe5, 0x5d, 0xff}},
+			[]byte{0x60, 0x80, 0x00, 0x00},
+			SinglePacket,
+			false,
+		},
+		{
+			"-malformed",
+			[][]byte{{0xfe, 0x00, 0x0e, 0x00, 0x01, 0xc0, 0x01, 0x02, 0x1e, 0x02, 0x60, 0xff}},
+			nil,
+			0,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, got1, err := SafeDecode(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SafeDecode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantData, got); diff != "" {
+				t.Fatalf("SafeDecode() = data %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantType, got1); diff != "" {
+				t.Fatalf("SafeDecode() = type %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetS101s(t *testing.T) {
 	t.Parallel()
 
@@ -701,4 +771,39 @@ func TestComputeCRCByte(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestKeepAlive(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff([]byte{0xfe, 0x00, 0x0e, 0x01, 0x01, 0x94, 0xe4, 0xff}, EncodeKeepAliveRequest()); diff != "" {
+		t.Fatalf("EncodeKeepAliveRequest() = %s", diff)
+	}
+
+	tests := []struct {
+		name         string
+		s101         []byte
+		wantRequest  bool
+		wantResponse bool
+	}{
+		{"+request", EncodeKeepAliveRequest(), true, false},
+		{"+response", EncodeKeepAliveResponse(), false, true},
+		{"-command", Encode([]byte{0x60, 0x80, 0x00, 0x00}, SinglePacket), false, false},
+		{"-short", []byte{0xfe, 0x00, 0x0e, 0x02, 0xff}, false, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsKeepAliveRequest(tt.s101); got != tt.wantRequest {
+				t.Fatalf("IsKeepAliveRequest() = %v, want %v", got, tt.wantRequest)
+			}
+
+			if got := IsKeepAliveResponse(tt.s101); got != tt.wantResponse {
+				t.Fatalf("IsKeepAliveResponse() = %v, want %v", got, tt.wantResponse)
+			}
+		})
+	}
+}
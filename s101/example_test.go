@@ -0,0 +1,39 @@
+package s101_test
+
+import (
+	"fmt"
+
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+func ExampleEncode() {
+	frame := s101.Encode([]byte{0x60, 0x80, 0x00, 0x00}, s101.SinglePacket)
+
+	fmt.Printf("% x\n", frame)
+	// Output: fe 00 0e 00 01 c0 01 02 28 02 60 80 00 00 e5 5d ff
+}
+
+func ExampleDecode() {
+	frames, incomplete, err := s101.GetS101s(s101.Encode([]byte{0x60, 0x80, 0x00, 0x00}, s101.SinglePacket))
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	if len(incomplete) > 0 {
+		fmt.Println("incomplete frame, read more data")
+
+		return
+	}
+
+	glow, packetType, err := s101.Decode(frames)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("% x, single packet: %v\n", glow, packetType == s101.SinglePacket)
+	// Output: 60 80 00 00, single packet: true
+}
@@ -14,6 +14,8 @@
 
 package s101
 
+import "errors"
+
 const (
 	// FirstMultiPacket is byte to identify a multilayer packet in S101.
 	FirstMultiPacket = 0x80
@@ -24,6 +26,13 @@ const (
 	// SinglePacket is byte to identify a single packet message in S101.
 	SinglePacket = 0xC0
 
+	// Version is the S101 framing version written into every packet.
+	Version = 1
+	// GlowMinorVersion is the Glow DTD minor version announced in every packet.
+	GlowMinorVersion = 40
+	// GlowMajorVersion is the Glow DTD major version announced in every packet.
+	GlowMajorVersion = 2
+
 	// BOF S101 packet opening byte.
 	bof = 0xfe
 	// Slot S101 packet Slot byte.
@@ -32,16 +41,16 @@ const (
 	messageType = 0x0e
 	// commandType defines that our S101 message is a command.
 	commandType = 0x00
-	// version defines version plugin uses for S101.
-	version = 1
+	// keepAliveRequest defines that our S101 message is a keep alive request.
+	keepAliveRequest = 0x01
+	// keepAliveResponse defines that our S101 message is a keep alive response.
+	keepAliveResponse = 0x02
+	// keepAliveLen is the length of an unescaped keep alive frame.
+	keepAliveLen = 8
 	// dtdType defines that plugin uses glow for S101 packet payload.
 	dtdType = 1
 	// appBytes defines how many application (version) byte will be used before payload.
 	appBytes = 2
-	// minorVersion Glow protocol minor version.
-	minorVersion = 40
-	// majorVersion Glow protocol major version.
-	majorVersion = 2
 	// eof is S101 packet end byte.
 	eof = 0xff
 	// ce is S101 packet biggest byte before the byte needs to be XOR.
@@ -57,10 +66,15 @@ const (
 	s101LenTilGlow = 10
 	// s101LenAfterGlow is offset for how many S101 bytes follow Glow payload till EOF.
 	s101LenAfterGlow = 3
+	// crcLen is the number of unescaped checksum bytes following the Glow payload.
+	crcLen = 2
 	// checkSumSecondDeviation is byte used in CRC calculations based on ember+ documentation.
 	checkSumSecondDeviation = 8
 )
 
+// ErrDecodePanic error returned by the safe decode entry points when decoding malformed data panicked.
+var ErrDecodePanic = errors.New("panic while decoding s101 data")
+
 //nolint:gochecknoglobals
 var crcTable = []uint16{
 	0x0000, 0x1189, 0x2312, 0x329b, 0x4624, 0x57ad, 0x6536, 0x74bf,
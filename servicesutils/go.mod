@@ -0,0 +1,20 @@
+module github.com/johannes-kuhfuss/emberplus/servicesutils
+
+go 1.23.2
+
+toolchain go1.23.3
+
+require (
+	github.com/johannes-kuhfuss/emberplus v0.0.0-20261016003557-f5c3e8d76df1
+	github.com/johannes-kuhfuss/services_utils v1.0.24
+)
+
+require (
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
+)
+
+// Builds inside the repository use the emberplus sources next to the module, users of the module get the required
+// version above.
+replace github.com/johannes-kuhfuss/emberplus => ../
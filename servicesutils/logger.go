@@ -0,0 +1,30 @@
+// Package servicesutils adapts the services_utils logger to the emberclient Logger interface. It is a module of its
+// own, so only programs setting it pull in services_utils:
+//
+//	ec.SetLogger(servicesutils.Logger{})
+package servicesutils
+
+import (
+	"github.com/johannes-kuhfuss/emberplus/emberclient"
+	"github.com/johannes-kuhfuss/services_utils/logger"
+)
+
+// Logger logs through the services_utils logger.
+type Logger struct{}
+
+var _ emberclient.Logger = Logger{}
+
+// Debugf logs the message with debug level.
+func (Logger) Debugf(msg string, a ...any) {
+	logger.Debugf(msg, a...)
+}
+
+// Infof logs the message with info level.
+func (Logger) Infof(msg string, a ...any) {
+	logger.Infof(msg, a...)
+}
+
+// Errorf logs the message with error level.
+func (Logger) Errorf(msg string, a ...any) {
+	logger.Errorf(msg, a...)
+}
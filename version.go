@@ -0,0 +1,58 @@
+// Package emberplus holds library wide information such as the library and protocol versions. The protocol
+// implementation itself lives in the asn1, s101, ember and emberclient packages. Only emberclient does network I/O,
+// the codecs in asn1, s101 and ember can be used on their own, e.g. in WASM builds providing their own transport.
+package emberplus
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/johannes-kuhfuss/emberplus/s101"
+)
+
+const (
+	// modulePath is used to find the library in the build information of the running binary.
+	modulePath = "github.com/johannes-kuhfuss/emberplus"
+	// develVersion is reported when no module version is available, e.g. when running from a source checkout.
+	develVersion = "(devel)"
+)
+
+// Version returns the version of the emberplus module compiled into the running binary, as recorded by the Go
+// toolchain, or "(devel)" if the version is unknown.
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return develVersion
+	}
+
+	return moduleVersion(info)
+}
+
+// ProtocolVersion returns the S101 framing and Glow DTD versions spoken by the library, e.g. "S101/1 Glow/2.40".
+func ProtocolVersion() string {
+	return fmt.Sprintf("S101/%d Glow/%d.%d", s101.Version, s101.GlowMajorVersion, s101.GlowMinorVersion)
+}
+
+// moduleVersion looks up the emberplus module in the build information, either as the main module or as a
+// dependency.
+func moduleVersion(info *debug.BuildInfo) string {
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			return dep.Replace.Version
+		}
+
+		if dep.Version != "" {
+			return dep.Version
+		}
+	}
+
+	return develVersion
+}
@@ -0,0 +1,69 @@
+package emberplus
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProtocolVersion(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff("S101/1 Glow/2.40", ProtocolVersion()); diff != "" {
+		t.Fatalf("ProtocolVersion() = %s", diff)
+	}
+}
+
+func TestModuleVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		info *debug.BuildInfo
+		want string
+	}{
+		{
+			"+main",
+			&debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "v1.2.3"}},
+			"v1.2.3",
+		},
+		{
+			"+dependency",
+			&debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/monitor", Version: "v0.1.0"},
+				Deps: []*debug.Module{
+					{Path: "github.com/google/go-cmp", Version: "v0.6.0"},
+					{Path: modulePath, Version: "v1.4.0"},
+				},
+			},
+			"v1.4.0",
+		},
+		{
+			"+replaced",
+			&debug.BuildInfo{
+				Deps: []*debug.Module{
+					{Path: modulePath, Version: "v1.4.0", Replace: &debug.Module{Path: "../emberplus", Version: "v1.4.1"}},
+				},
+			},
+			"v1.4.1",
+		},
+		{
+			"+unknown",
+			&debug.BuildInfo{Main: debug.Module{Path: "example.com/monitor", Version: "v0.1.0"}},
+			"(devel)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := moduleVersion(tt.info)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("moduleVersion() = %s", diff)
+			}
+		})
+	}
+}